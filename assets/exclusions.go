@@ -0,0 +1,44 @@
+package assets
+
+// ExclusionProfiles are the built-in sets of path exclusion patterns that
+// can be enabled per platform with the ExclusionProfiles parameter.
+var ExclusionProfiles = map[string][]string{
+	"vendored": {
+		"node_modules/",
+		"bower_components/",
+		"jspm_packages/",
+		"vendor/",
+		"third_party/",
+		"third-party/",
+		"thirdparty/",
+		"Pods/",
+		"Carthage/",
+		".venv/",
+		"venv/",
+		"site-packages/",
+	},
+	"build-output": {
+		"dist/",
+		"build/",
+		"out/",
+		"target/",
+		"bin/",
+		"obj/",
+		".next/",
+		".nuxt/",
+		".gradle/",
+		"__pycache__/",
+		"coverage/",
+	},
+	"ide": {
+		".idea/",
+		".vscode/",
+		".vs/",
+		".settings/",
+		".fleet/",
+		".history/",
+		".project",
+		".classpath",
+		"*.iml",
+	},
+}
@@ -2,170 +2,520 @@ package assets
 
 import "github.com/colussim/GoLC/pkg/goloc/language"
 
+var (
+	cStrings = []language.StringLiteral{
+		{Start: "\"", End: "\"", Escape: "\\"},
+		{Start: "'", End: "'", Escape: "\\"},
+	}
+	sqlStrings = []language.StringLiteral{
+		{Start: "'", End: "'", Escape: "'"},
+		{Start: "\"", End: "\"", Escape: "\""},
+	}
+	jsStrings = append([]language.StringLiteral{
+		{Start: "`", End: "`", Escape: "\\", MultiLine: true},
+	}, cStrings...)
+	// Triple quotes are also the block comments of Python, when they
+	// start a statement.
+	pythonStrings = append([]language.StringLiteral{
+		{Start: "\"\"\"", End: "\"\"\"", Escape: "\\", MultiLine: true},
+		{Start: "'''", End: "'''", Escape: "\\", MultiLine: true},
+	}, cStrings...)
+
+	// Test conventions shared by several languages.
+	jvmTests = []string{"**/src/test/"}
+	jsTests  = []string{"*.spec.*", "*.test.*", "__tests__/", "__mocks__/"}
+
+	// Sections of HTML pages and components, the lang or type attribute
+	// of the tag selects the language.
+	scriptSection = language.Section{
+		Open:     `(?i)<script\b(?:[^>]*?\b(?:lang|type)\s*=\s*["']?(?P<lang>[\w/+-]+))?[^>]*>`,
+		Close:    `(?i)</script\s*>`,
+		Language: "JavaScript",
+		Languages: map[string]string{
+			"ts":                         "TypeScript",
+			"tsx":                        "TypeScript",
+			"typescript":                 "TypeScript",
+			"text/typescript":            "TypeScript",
+			"text/html":                  "HTML",
+			"text/x-template":            "HTML",
+			"text/ng-template":           "HTML",
+			"text/x-handlebars":          "HTML",
+			"text/x-handlebars-template": "HTML",
+		},
+	}
+	styleSection = language.Section{
+		Open:     `(?i)<style\b(?:[^>]*?\blang\s*=\s*["']?(?P<lang>[\w-]+))?[^>]*>`,
+		Close:    `(?i)</style\s*>`,
+		Language: "CSS",
+		Languages: map[string]string{
+			"scss": "Scss",
+			"sass": "Sass",
+			"less": "Less",
+		},
+	}
+	htmlSections = []language.Section{scriptSection, styleSection}
+)
+
 var Languages = language.Languages{
 	"ActionScript": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".as"},
+		Strings:           cStrings,
 	},
 	"Abap": {
 		LineComments:      []string{"\""},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".abap", ".ab4", ".flow"},
+		Strings: []language.StringLiteral{
+			{Start: "'", End: "'", Escape: "'"},
+			{Start: "`", End: "`", Escape: "`"},
+			{Start: "|", End: "|", Escape: "\\"},
+		},
 	},
 	"Apex": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".cls", ".trigger"},
+		Strings:           cStrings,
 	},
 	"C": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".c"},
+		Strings:           cStrings,
 	},
 	"C Header": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".h"},
+		Strings:           cStrings,
 	},
 	"C++": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".cpp", ".cc"},
+		Strings: append([]language.StringLiteral{
+			{Start: "R\"(", End: ")\"", MultiLine: true},
+		}, cStrings...),
 	},
 	"C++ Header": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
-		Extensions:        []string{".hh", ".hpp"},
+		Extensions:        []string{".hh", ".hpp", ".hxx", ".h"},
+		Strings: append([]language.StringLiteral{
+			{Start: "R\"(", End: ")\"", MultiLine: true},
+		}, cStrings...),
+		Heuristics: []language.Heuristic{
+			{
+				Extensions: []string{".h"},
+				Patterns:   []string{`^\s*(class|namespace)\s+\w+`, `^\s*template\s*<`, `\bstd::`, `^\s*#include\s*<(iostream|string|vector|map|memory)>`},
+				Siblings:   []string{".cpp", ".cc", ".cxx", ".hpp"},
+			},
+		},
 	},
 	"COBOL": {
-		LineComments:      []string{"*", "/"},
+		LineComments:      []string{"*>"},
 		MultiLineComments: [][]string{},
-		Extensions:        []string{".cbl", ".ccp", ".cob", ".cobol", ".cpy"},
+		Columns: language.ColumnRules{
+			CodeStart: 8,
+			CodeEnd:   72,
+			Comments:  []language.ColumnComment{{Column: 7, Indicator: "*"}, {Column: 7, Indicator: "/"}},
+			FreeFormat: []string{
+				`(?i)>>\s*SOURCE\s+(FORMAT\s+)?(IS\s+)?FREE`,
+				`(?i)\$\s*SET\s+SOURCEFORMAT\s*[("']+FREE`,
+			},
+			FixedFormat: []string{
+				`(?i)>>\s*SOURCE\s+(FORMAT\s+)?(IS\s+)?FIXED`,
+				`(?i)\$\s*SET\s+SOURCEFORMAT\s*[("']+FIXED`,
+			},
+		},
+		Extensions: []string{".cbl", ".ccp", ".cob", ".cobol", ".cpy"},
+		Strings:    sqlStrings,
 	},
 	"C#": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".cs"},
+		Tests:             []string{"*Test.cs", "*Tests.cs"},
+		Strings: append([]language.StringLiteral{
+			{Start: "@\"", End: "\"", Escape: "\"", MultiLine: true},
+			{Start: "\"\"\"", End: "\"\"\"", MultiLine: true},
+		}, cStrings...),
+	},
+	"CMake": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{{"#[[", "]]"}},
+		Extensions:        []string{".cmake"},
+		Filenames:         []string{"CMakeLists.txt"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\", MultiLine: true},
+		},
+	},
+	"D": {
+		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{{"/*", "*/"}, {"/+", "+/", language.Nested}},
+		Extensions:        []string{".d"},
+		Strings: append([]language.StringLiteral{
+			{Start: "`", End: "`", MultiLine: true},
+			{Start: "r\"", End: "\"", MultiLine: true},
+		}, cStrings...),
+	},
+	"Dockerfile": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".dockerfile"},
+		Filenames:         []string{"Dockerfile", "Containerfile", "Dockerfile.*", "*.Dockerfile"},
+		Strings:           cStrings,
+		Heredocs:          []string{"<<"},
 	},
 	"CSS": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".css"},
+		Strings:           cStrings,
 	},
 	"Golang": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".go"},
+		Tests:             []string{"*_test.go"},
+		Strings: append([]language.StringLiteral{
+			{Start: "`", End: "`", MultiLine: true},
+		}, cStrings...),
+	},
+	"Groovy": {
+		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
+		Extensions:        []string{".groovy", ".gvy", ".gradle"},
+		Tests:             []string{"**/src/test/", "*Spec.groovy"},
+		Filenames:         []string{"Jenkinsfile", "Jenkinsfile.*"},
+		Shebangs:          []string{"groovy"},
+		Strings: append([]language.StringLiteral{
+			{Start: "\"\"\"", End: "\"\"\"", Escape: "\\", MultiLine: true},
+			{Start: "'''", End: "'''", Escape: "\\", MultiLine: true},
+		}, cStrings...),
+	},
+	"Haskell": {
+		LineComments:      []string{"--"},
+		MultiLineComments: [][]string{{"{-", "-}", language.Nested}},
+		Extensions:        []string{".hs"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\"},
+		},
 	},
 	"HTML": {
 		LineComments:      []string{},
 		MultiLineComments: [][]string{{"<!--", "-->"}},
 		Extensions:        []string{".html", ".htm", ".cshtml", ".vbhtml", ".aspx", ".ascx", ".rhtml", ".erb", ".shtml", ".shtm", "cmp"},
+		Sections:          htmlSections,
 	},
 	"Java": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".java", ".jav"},
+		Tests:             jvmTests,
+		Strings: append([]language.StringLiteral{
+			{Start: "\"\"\"", End: "\"\"\"", Escape: "\\", MultiLine: true},
+		}, cStrings...),
 	},
 	"JavaScript": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
-		Extensions:        []string{".js", ".jsx", ".jsp", ".jspf"},
+		Extensions:        []string{".js", ".jsx"},
+		Tests:             jsTests,
+		Shebangs:          []string{"node", "nodejs"},
+		Strings:           jsStrings,
+	},
+	"JSP": {
+		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{{"<%--", "--%>"}, {"/*", "*/"}},
+		Extensions:        []string{".jsp", ".jspf"},
+		Strings:           cStrings,
+		Host:              "HTML",
+		Sections: []language.Section{
+			{Open: `<%`, Close: `%>`, Language: "JSP", Inclusive: true},
+		},
+	},
+	"Jupyter Notebook": {
+		LineComments:      []string{},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".ipynb"},
+		Notebook:          true,
 	},
 	"Kotlin": {
 		LineComments:      []string{"//"},
-		MultiLineComments: [][]string{{"/*", "*/"}},
+		MultiLineComments: [][]string{{"/*", "*/", language.Nested}},
 		Extensions:        []string{".kt", ".kts"},
+		Tests:             jvmTests,
+		Strings: append([]language.StringLiteral{
+			{Start: "\"\"\"", End: "\"\"\"", MultiLine: true},
+		}, cStrings...),
+	},
+	"MATLAB": {
+		LineComments:      []string{"%"},
+		MultiLineComments: [][]string{{"%{", "%}"}},
+		Extensions:        []string{".m"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\""},
+		},
+		Heuristics: []language.Heuristic{
+			{
+				Extensions: []string{".m"},
+				Patterns:   []string{`^\s*function\b`, `^\s*%`, `^\s*end\s*;?\s*$`},
+			},
+		},
+	},
+	"Makefile": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".mk", ".mak"},
+		Filenames:         []string{"Makefile", "makefile", "GNUmakefile"},
+		Shebangs:          []string{"make"},
 	},
 	"Flex": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
-		Extensions:        []string{".as"},
+		Extensions:        []string{".as", ".mxml"},
+		Strings:           cStrings,
+		Heuristics: []language.Heuristic{
+			{
+				Extensions: []string{".as", ".mxml"},
+				Patterns:   []string{`^\s*import\s+mx\.`, `\[Bindable\]`},
+				Siblings:   []string{".mxml"},
+			},
+		},
 	},
 	"PHP": {
 		LineComments:      []string{"//", "#"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".php", ".php3", ".php4", ".php5", ".phtml", ".inc"},
+		Tests:             []string{"*Test.php"},
+		Shebangs:          []string{"php"},
+		Strings:           cStrings,
+		Heredocs:          []string{"<<<"},
+		Host:              "HTML",
+		Sections: []language.Section{
+			{Open: `(?i)<\?(?:php\b|=|\s|$)`, Close: `\?>`, Language: "PHP", Inclusive: true},
+		},
 	},
 	"Objective-C": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
-		Extensions:        []string{".m"},
+		Extensions:        []string{".m", ".h"},
+		Strings:           cStrings,
+		Heuristics: []language.Heuristic{
+			{
+				Extensions: []string{".h"},
+				Patterns:   []string{`^\s*@(interface|protocol|property|end)\b`, `^\s*#import\s`},
+				Siblings:   []string{".m"},
+			},
+		},
 	},
 	"Oracle PL/SQL": {
 		LineComments:      []string{"--"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".pkb"},
+		Strings:           sqlStrings,
+	},
+	"Perl": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{{"=pod", "=cut"}, {"=head", "=cut"}, {"=begin", "=cut"}},
+		Extensions:        []string{".pl", ".pm", ".t"},
+		Shebangs:          []string{"perl"},
+		Strings:           cStrings,
+		Heredocs:          []string{"<<"},
+		Heuristics: []language.Heuristic{
+			{
+				Extensions: []string{".pl"},
+				Patterns:   []string{`^\s*use\s+(strict|warnings)\b`, `^\s*(my|our|sub)\s`},
+			},
+		},
 	},
 	"PL/I": {
 		LineComments:      []string{"--"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".pl1"},
+		Strings:           sqlStrings,
+	},
+	"Prolog": {
+		LineComments:      []string{"%"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
+		Extensions:        []string{".pl", ".prolog"},
+		Strings:           cStrings,
+		Heuristics: []language.Heuristic{
+			{
+				Extensions: []string{".pl"},
+				Patterns:   []string{`^\s*:-`, `^[a-z]\w*(\(.*\))?\s*:-`},
+			},
+		},
 	},
 	"Python": {
 		LineComments:      []string{"#"},
-		MultiLineComments: [][]string{{"\"\"\"", "\"\"\""}},
+		MultiLineComments: [][]string{{"\"\"\"", "\"\"\""}, {"'''", "'''"}},
 		Extensions:        []string{".py"},
+		Tests:             []string{"test_*.py", "*_test.py", "conftest.py"},
+		Filenames:         []string{"SConstruct", "SConscript"},
+		Shebangs:          []string{"python"},
+		Strings:           pythonStrings,
 	},
 
 	"RPG": {
-		LineComments:      []string{"#"},
+		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{},
-		Extensions:        []string{".rpg"},
+		Columns: language.ColumnRules{
+			CodeStart:  6,
+			CodeEnd:    80,
+			Comments:   []language.ColumnComment{{Column: 7, Indicator: "*"}},
+			FreeFormat: []string{`(?i)^\*\*FREE`},
+		},
+		Extensions: []string{".rpg", ".rpgle", ".sqlrpgle", ".rpgleinc"},
+		Strings: []language.StringLiteral{
+			{Start: "'", End: "'", Escape: "'"},
+		},
 	},
 	"Ruby": {
 		LineComments:      []string{"#"},
 		MultiLineComments: [][]string{{"=begin", "=end"}},
 		Extensions:        []string{".rb"},
+		Tests:             []string{"*_spec.rb", "*_test.rb", "spec/", "test/"},
+		Filenames:         []string{"Rakefile", "Gemfile", "Vagrantfile", "Podfile", "Fastfile", "Guardfile", "Brewfile"},
+		Shebangs:          []string{"ruby"},
+		Strings:           cStrings,
+		Heredocs:          []string{"<<"},
+	},
+	"Rust": {
+		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{{"/*", "*/", language.Nested}},
+		Extensions:        []string{".rs"},
+		Tests:             []string{"tests/"},
+		// A quote also starts a lifetime ('a), so only the char literals
+		// holding a double quote or an escape sequence are strings.
+		Strings: []language.StringLiteral{
+			{Start: "r#\"", End: "\"#", MultiLine: true},
+			{Start: "\"", End: "\"", Escape: "\\", MultiLine: true},
+			{Start: "'\"", End: "'"},
+			{Start: "'\\", End: "'"},
+		},
 	},
 	"Scala": {
 		LineComments:      []string{"//"},
-		MultiLineComments: [][]string{{"/*", "*/"}},
+		MultiLineComments: [][]string{{"/*", "*/", language.Nested}},
 		Extensions:        []string{".scala"},
+		Tests:             jvmTests,
+		Strings: append([]language.StringLiteral{
+			{Start: "\"\"\"", End: "\"\"\"", MultiLine: true},
+		}, cStrings...),
 	},
 	"Scss": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".scss"},
+		Strings:           cStrings,
+	},
+	"Shell": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".sh", ".bash", ".zsh", ".ksh"},
+		Filenames:         []string{".bashrc", ".bash_profile", ".profile", ".zshrc"},
+		Shebangs:          []string{"sh", "bash", "zsh", "ksh", "dash", "ash"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\"},
+			{Start: "'", End: "'"},
+		},
+		Heredocs: []string{"<<"},
+	},
+	"Starlark": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{{"\"\"\"", "\"\"\""}, {"'''", "'''"}},
+		Extensions:        []string{".bzl", ".star"},
+		Filenames:         []string{"BUILD", "BUILD.bazel", "WORKSPACE", "WORKSPACE.bazel", "MODULE.bazel", "Tiltfile"},
+		Strings:           pythonStrings,
 	},
 	"SQL": {
 		LineComments:      []string{"--"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".sql"},
+		Strings:           sqlStrings,
 	},
 	"Swift": {
 		LineComments:      []string{"//"},
-		MultiLineComments: [][]string{{"/*", "*/"}},
+		MultiLineComments: [][]string{{"/*", "*/", language.Nested}},
 		Extensions:        []string{".swift"},
+		Tests:             []string{"*Tests.swift"},
+		Strings: append([]language.StringLiteral{
+			{Start: "\"\"\"", End: "\"\"\"", Escape: "\\", MultiLine: true},
+		}, cStrings...),
 	},
 	"TypeScript": {
 		LineComments:      []string{"//"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".ts", ".tsx"},
+		Tests:             jsTests,
+		Strings:           jsStrings,
 	},
 	"T-SQL": {
 		LineComments:      []string{"--"},
-		MultiLineComments: [][]string{},
+		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".tsql"},
+		Strings:           sqlStrings,
+	},
+	"TeX": {
+		LineComments:      []string{"%"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".tex", ".sty", ".cls"},
+		Heuristics: []language.Heuristic{
+			{
+				Extensions: []string{".cls"},
+				Patterns:   []string{`\\(documentclass|ProvidesClass|NeedsTeXFormat|LoadClass)\b`},
+			},
+		},
 	},
 	"Vue": {
-		LineComments:      []string{"<!--"},
+		LineComments:      []string{},
 		MultiLineComments: [][]string{{"<!--", "-->"}},
 		Extensions:        []string{".vue"},
+		Sections: []language.Section{
+			{
+				Open:      `(?i)<template\b(?:[^>]*?\blang\s*=\s*["']?(?P<lang>[\w-]+))?[^>]*>`,
+				Close:     `(?i)</template\s*>`,
+				Language:  "HTML",
+				Languages: map[string]string{"html": "HTML"},
+				Nested:    true,
+			},
+			scriptSection,
+			styleSection,
+		},
 	},
 	"Visual Basic .NET": {
 		LineComments:      []string{"'"},
 		MultiLineComments: [][]string{},
 		Extensions:        []string{".vb"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\""},
+		},
+	},
+	"VBA": {
+		LineComments:      []string{"'", "Rem ", "REM "},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".bas", ".cls", ".frm"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\""},
+		},
+		Heuristics: []language.Heuristic{
+			{
+				Extensions: []string{".cls"},
+				Patterns:   []string{`^VERSION \d+\.\d+ CLASS`, `^Attribute VB_`},
+			},
+		},
 	},
 	"XML": {
-		LineComments:      []string{"<!--"},
+		LineComments:      []string{},
 		MultiLineComments: [][]string{{"<!--", "-->"}},
 		Extensions:        []string{".xml", ".XML"},
 	},
 	"XHTML": {
-		LineComments:      []string{"<!--"},
+		LineComments:      []string{},
 		MultiLineComments: [][]string{{"<!--", "-->"}},
 		Extensions:        []string{".xhtml"},
 	},
@@ -173,15 +523,389 @@ var Languages = language.Languages{
 		LineComments:      []string{"#"},
 		MultiLineComments: [][]string{},
 		Extensions:        []string{".yaml", ".yml"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\"},
+			{Start: "'", End: "'", Escape: "'"},
+		},
 	},
 	"Terraform": {
-		LineComments:      []string{},
-		MultiLineComments: [][]string{},
+		LineComments:      []string{"#", "//"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
 		Extensions:        []string{".tf"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\"},
+		},
+		Heredocs: []string{"<<"},
 	},
 	"JCL": {
+		LineComments:      []string{},
+		MultiLineComments: [][]string{},
+		Columns: language.ColumnRules{
+			CodeEnd:  71,
+			Comments: []language.ColumnComment{{Column: 1, Indicator: "//*"}},
+		},
+		Extensions: []string{".jcl", ".JCL"},
+		Strings: []language.StringLiteral{
+			{Start: "'", End: "'", Escape: "'"},
+		},
+	},
+	"Ada": {
+		LineComments:      []string{"--"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".adb", ".ads", ".ada"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\""},
+		},
+	},
+	"AWK": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".awk"},
+		Shebangs:          []string{"awk", "gawk", "mawk", "nawk"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\"},
+		},
+	},
+	"Batch": {
+		LineComments:      []string{"::", "REM ", "rem ", "Rem ", "@REM ", "@rem "},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".bat", ".cmd"},
+	},
+	"Clojure": {
+		LineComments:      []string{";"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".clj", ".cljs", ".cljc", ".edn"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\", MultiLine: true},
+		},
+	},
+	"CUDA": {
+		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
+		Extensions:        []string{".cu", ".cuh"},
+		Strings:           cStrings,
+	},
+	"Dart": {
+		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{{"/*", "*/", language.Nested}},
+		Extensions:        []string{".dart"},
+		Tests:             []string{"*_test.dart"},
+		Strings: append([]language.StringLiteral{
+			{Start: "\"\"\"", End: "\"\"\"", Escape: "\\", MultiLine: true},
+			{Start: "'''", End: "'''", Escape: "\\", MultiLine: true},
+		}, cStrings...),
+	},
+	"Elixir": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".ex", ".exs"},
+		Tests:             []string{"*_test.exs"},
+		Filenames:         []string{"mix.lock"},
+		Shebangs:          []string{"elixir"},
+		Strings: []language.StringLiteral{
+			{Start: "\"\"\"", End: "\"\"\"", Escape: "\\", MultiLine: true},
+			{Start: "'''", End: "'''", Escape: "\\", MultiLine: true},
+			{Start: "\"", End: "\"", Escape: "\\", MultiLine: true},
+			{Start: "'", End: "'", Escape: "\\"},
+		},
+	},
+	"Elm": {
+		LineComments:      []string{"--"},
+		MultiLineComments: [][]string{{"{-", "-}", language.Nested}},
+		Extensions:        []string{".elm"},
+		Strings: []language.StringLiteral{
+			{Start: "\"\"\"", End: "\"\"\"", Escape: "\\", MultiLine: true},
+			{Start: "\"", End: "\"", Escape: "\\"},
+		},
+	},
+	"Emacs Lisp": {
+		LineComments:      []string{";"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".el"},
+		Filenames:         []string{".emacs", "_emacs"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\", MultiLine: true},
+		},
+	},
+	"Erlang": {
+		LineComments:      []string{"%"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".erl", ".hrl"},
+		Filenames:         []string{"rebar.config", "rebar.config.script"},
+		Shebangs:          []string{"escript"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\"},
+		},
+	},
+	"F#": {
 		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{{"(*", "*)", language.Nested}},
+		Extensions:        []string{".fs", ".fsi", ".fsx"},
+		Strings: []language.StringLiteral{
+			{Start: "\"\"\"", End: "\"\"\"", MultiLine: true},
+			{Start: "@\"", End: "\"", Escape: "\"", MultiLine: true},
+			{Start: "\"", End: "\"", Escape: "\\", MultiLine: true},
+		},
+	},
+	"Fortran": {
+		LineComments:      []string{"!"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".f90", ".f95", ".f03", ".f08", ".F90", ".F95", ".F03", ".F08"},
+		Strings:           sqlStrings,
+	},
+	"Fortran 77": {
+		LineComments:      []string{"!"},
+		MultiLineComments: [][]string{},
+		Columns: language.ColumnRules{
+			CodeEnd: 72,
+			Comments: []language.ColumnComment{
+				{Column: 1, Indicator: "C"},
+				{Column: 1, Indicator: "c"},
+				{Column: 1, Indicator: "*"},
+				{Column: 1, Indicator: "!"},
+			},
+		},
+		Extensions: []string{".f", ".for", ".f77", ".F", ".FOR", ".F77"},
+		Strings:    sqlStrings,
+	},
+	"GLSL": {
+		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
+		Extensions:        []string{".glsl", ".vert", ".frag", ".geom", ".comp", ".tesc", ".tese"},
+	},
+	"Go Template": {
+		LineComments:      []string{},
+		MultiLineComments: [][]string{{"{{/*", "*/}}"}, {"{{- /*", "*/ -}}"}},
+		Extensions:        []string{".tmpl", ".gotmpl", ".gohtml"},
+	},
+	"GraphQL": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".graphql", ".graphqls", ".gql"},
+		Strings: []language.StringLiteral{
+			{Start: "\"\"\"", End: "\"\"\"", MultiLine: true},
+			{Start: "\"", End: "\"", Escape: "\\"},
+		},
+	},
+	"HCL": {
+		LineComments:      []string{"#", "//"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
+		Extensions:        []string{".hcl", ".nomad"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\"},
+		},
+		Heredocs: []string{"<<"},
+	},
+	"INI": {
+		LineComments:      []string{";", "#"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".ini"},
+		Filenames:         []string{".editorconfig", ".gitconfig"},
+	},
+	"Jinja": {
+		LineComments:      []string{},
+		MultiLineComments: [][]string{{"{#", "#}"}},
+		Extensions:        []string{".j2", ".jinja", ".jinja2"},
+	},
+	"JSON5": {
+		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
+		Extensions:        []string{".json5", ".jsonc"},
+		Strings:           cStrings,
+	},
+	"Jsonnet": {
+		LineComments:      []string{"//", "#"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
+		Extensions:        []string{".jsonnet", ".libsonnet"},
+		Strings: append([]language.StringLiteral{
+			{Start: "|||", End: "|||", MultiLine: true},
+		}, cStrings...),
+	},
+	"Julia": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{{"#=", "=#", language.Nested}},
+		Extensions:        []string{".jl"},
+		Shebangs:          []string{"julia"},
+		Strings: []language.StringLiteral{
+			{Start: "\"\"\"", End: "\"\"\"", Escape: "\\", MultiLine: true},
+			{Start: "\"", End: "\"", Escape: "\\", MultiLine: true},
+		},
+	},
+	"Less": {
+		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
+		Extensions:        []string{".less"},
+		Strings:           cStrings,
+	},
+	"Lisp": {
+		LineComments:      []string{";"},
+		MultiLineComments: [][]string{{"#|", "|#", language.Nested}},
+		Extensions:        []string{".lisp", ".lsp", ".asd"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\", MultiLine: true},
+		},
+	},
+	"Lua": {
+		LineComments:      []string{"--"},
+		MultiLineComments: [][]string{{"--[[", "]]"}, {"--[=[", "]=]"}, {"--[==[", "]==]"}},
+		Extensions:        []string{".lua"},
+		Shebangs:          []string{"lua", "luajit"},
+		Strings: append([]language.StringLiteral{
+			{Start: "[[", End: "]]", MultiLine: true},
+			{Start: "[=[", End: "]=]", MultiLine: true},
+		}, cStrings...),
+	},
+	"Markdown": {
+		LineComments:      []string{},
+		MultiLineComments: [][]string{{"<!--", "-->"}},
+		Extensions:        []string{".md", ".markdown", ".mdown", ".mkd"},
+	},
+	"Meson": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{},
+		Filenames:         []string{"meson.build", "meson_options.txt", "meson.options"},
+		Strings: []language.StringLiteral{
+			{Start: "'''", End: "'''", MultiLine: true},
+			{Start: "'", End: "'", Escape: "\\"},
+		},
+	},
+	"Nim": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{{"#[", "]#", language.Nested}},
+		Extensions:        []string{".nim", ".nims", ".nimble"},
+		Strings: append([]language.StringLiteral{
+			{Start: "\"\"\"", End: "\"\"\"", MultiLine: true},
+		}, cStrings...),
+	},
+	"Nix": {
+		LineComments:      []string{"#"},
 		MultiLineComments: [][]string{{"/*", "*/"}},
-		Extensions:        []string{".jcl", ".JCL"},
+		Extensions:        []string{".nix"},
+		Strings: []language.StringLiteral{
+			{Start: "''", End: "''", MultiLine: true},
+			{Start: "\"", End: "\"", Escape: "\\", MultiLine: true},
+		},
+	},
+	"OCaml": {
+		LineComments:      []string{},
+		MultiLineComments: [][]string{{"(*", "*)", language.Nested}},
+		Extensions:        []string{".ml", ".mli", ".mll", ".mly"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\", MultiLine: true},
+		},
+	},
+	"Pascal": {
+		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{{"{", "}"}, {"(*", "*)"}},
+		Extensions:        []string{".pas", ".dpr", ".dpk", ".lpr"},
+		Strings: []language.StringLiteral{
+			{Start: "'", End: "'", Escape: "'"},
+		},
+	},
+	"PowerShell": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{{"<#", "#>"}},
+		Extensions:        []string{".ps1", ".psm1", ".psd1"},
+		Shebangs:          []string{"pwsh", "powershell"},
+		Strings: []language.StringLiteral{
+			{Start: "@\"", End: "\"@", MultiLine: true},
+			{Start: "@'", End: "'@", MultiLine: true},
+			{Start: "\"", End: "\"", Escape: "`", MultiLine: true},
+			{Start: "'", End: "'", Escape: "'", MultiLine: true},
+		},
+	},
+	"Protobuf": {
+		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
+		Extensions:        []string{".proto"},
+		Strings:           cStrings,
+	},
+	"R": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".r", ".R"},
+		Filenames:         []string{".Rprofile"},
+		Shebangs:          []string{"Rscript"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\", MultiLine: true},
+			{Start: "'", End: "'", Escape: "\\", MultiLine: true},
+		},
+	},
+	"Sass": {
+		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
+		Extensions:        []string{".sass"},
+		Strings:           cStrings,
+	},
+	"Scheme": {
+		LineComments:      []string{";"},
+		MultiLineComments: [][]string{{"#|", "|#", language.Nested}},
+		Extensions:        []string{".scm", ".ss", ".sld", ".rkt"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\", MultiLine: true},
+		},
+	},
+	"Solidity": {
+		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
+		Extensions:        []string{".sol"},
+		Strings:           cStrings,
+	},
+	"Svelte": {
+		LineComments:      []string{},
+		MultiLineComments: [][]string{{"<!--", "-->"}},
+		Extensions:        []string{".svelte"},
+		Sections:          htmlSections,
+	},
+	"Tcl": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".tcl", ".tk"},
+		Shebangs:          []string{"tclsh", "wish"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\", MultiLine: true},
+		},
+	},
+	"Thrift": {
+		LineComments:      []string{"//", "#"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
+		Extensions:        []string{".thrift"},
+		Strings:           cStrings,
+	},
+	"TOML": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".toml"},
+		Filenames:         []string{"Cargo.lock", "Pipfile", "poetry.lock"},
+		Strings: []language.StringLiteral{
+			{Start: "\"\"\"", End: "\"\"\"", Escape: "\\", MultiLine: true},
+			{Start: "'''", End: "'''", MultiLine: true},
+			{Start: "\"", End: "\"", Escape: "\\"},
+			{Start: "'", End: "'"},
+		},
+	},
+	"VBScript": {
+		LineComments:      []string{"'", "Rem ", "REM "},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".vbs"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\""},
+		},
+	},
+	"VHDL": {
+		LineComments:      []string{"--"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
+		Extensions:        []string{".vhd", ".vhdl"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\""},
+		},
+	},
+	"Zig": {
+		LineComments:      []string{"//"},
+		MultiLineComments: [][]string{},
+		Extensions:        []string{".zig"},
+		Strings: []language.StringLiteral{
+			{Start: "\"", End: "\"", Escape: "\\"},
+		},
 	},
 }
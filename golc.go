@@ -3,24 +3,29 @@ package main
 import (
 	"archive/zip"
 	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
-	"github.com/briandowns/spinner"
-
 	"github.com/colussim/GoLC/assets"
+	"github.com/colussim/GoLC/pkg/events"
 	"github.com/colussim/GoLC/pkg/goloc"
+	"github.com/colussim/GoLC/pkg/goloc/language"
+	"github.com/colussim/GoLC/pkg/sorter"
 
 	"github.com/colussim/GoLC/pkg/devops/getazure"
 	getbibucket "github.com/colussim/GoLC/pkg/devops/getbitbucket/v2"
@@ -31,12 +36,13 @@ import (
 )
 
 type OrganizationData struct {
-	Organization           string `json:"Organization"`
-	TotalLinesOfCode       string `json:"TotalLinesOfCode"`
-	LargestRepository      string `json:"LargestRepository"`
-	LinesOfCodeLargestRepo string `json:"LinesOfCodeLargestRepo"`
-	DevOpsPlatform         string `json:"DevOpsPlatform"`
-	NumberRepos            int    `json:"NumberRepos"`
+	Organization           string       `json:"Organization"`
+	TotalLinesOfCode       string       `json:"TotalLinesOfCode"`
+	LargestRepository      string       `json:"LargestRepository"`
+	LinesOfCodeLargestRepo string       `json:"LinesOfCodeLargestRepo"`
+	DevOpsPlatform         string       `json:"DevOpsPlatform"`
+	NumberRepos            int          `json:"NumberRepos"`
+	FailedRepositories     []FailedRepo `json:"FailedRepositories,omitempty"`
 }
 
 type Repository struct {
@@ -64,39 +70,27 @@ type SelfLink struct {
 }
 
 type Config struct {
-	Platforms map[string]interface{} `json:"platforms"`
-	Logging   LoggingConfig          `json:"logging"`
+	Platforms     map[string]interface{} `json:"platforms"`
+	Logging       LoggingConfig          `json:"logging"`
+	LanguagesFile string                 `json:"LanguagesFile"`
 }
 
 type LoggingConfig struct {
 	Level logrus.Level `json:"level"`
 }
 
-type Report struct {
-	TotalFiles      int `json:",omitempty"`
-	TotalLines      int
-	TotalBlankLines int
-	TotalComments   int
-	TotalCodeLines  int
-	Results         interface{}
-}
-
-type Result struct {
-	TotalFiles      int           `json:"TotalFiles"`
-	TotalLines      int           `json:"TotalLines"`
-	TotalBlankLines int           `json:"TotalBlankLines"`
-	TotalComments   int           `json:"TotalComments"`
-	TotalCodeLines  int           `json:"TotalCodeLines"`
-	Results         []LanguageRes `json:"Results"`
+// Lines of code of an analyzed repository or directory
+type RepoResult struct {
+	Project   string
+	Repo      string
+	CodeLines int
 }
 
-type LanguageRes struct {
-	Language   string `json:"Language"`
-	Files      int    `json:"Files"`
-	Lines      int    `json:"Lines"`
-	BlankLines int    `json:"BlankLines"`
-	Comments   int    `json:"Comments"`
-	CodeLines  int    `json:"CodeLines"`
+// Repository or directory whose analysis failed or timed out
+type FailedRepo struct {
+	Project string `json:"Project,omitempty"`
+	Repo    string `json:"Repo"`
+	Error   string `json:"Error"`
 }
 
 type RepoParams struct {
@@ -121,6 +115,24 @@ const directoryconf = "/config"
 var logFile *os.File
 var AppConfig Config
 var logger *logrus.Logger
+var customLanguages language.Languages
+var languageOrigins map[string]string
+var repoResults []RepoResult
+var failedRepos []FailedRepo
+var repoResultsMutex sync.Mutex
+var appContext = context.Background()
+
+// Record the results of an analyzed repository or directory for the global report
+func addRepoResult(project, repo string, summary *sorter.SortedSummary) {
+	repoResultsMutex.Lock()
+	defer repoResultsMutex.Unlock()
+
+	repoResults = append(repoResults, RepoResult{
+		Project:   project,
+		Repo:      repo,
+		CodeLines: summary.TotalCodeLines,
+	})
+}
 
 // Check Exclusion File Exist
 func getFileNameIfExists(filePath string) string {
@@ -157,31 +169,153 @@ func LoadConfig(filename string) (Config, error) {
 	return config, nil
 }
 
-// Parse Result Files in JSON Format
-func parseJSONFile(filePath, reponame string) int {
-	file, err := os.ReadFile(filePath)
-	if err != nil {
-		//fmt.Println("❌ Error reading file:", err)
-		logger.Errorf("❌ Error reading file:", err)
+// convert To Slice String
+func convertToSliceString(in []interface{}) []string {
+	out := make([]string, len(in))
+	for i, v := range in {
+		out[i] = v.(string)
+	}
+	return out
+}
+
+// Read an optional boolean from the platform configuration
+func getConfigBool(platformConfig map[string]interface{}, key string) bool {
+	value, ok := platformConfig[key].(bool)
+	return ok && value
+}
+
+// Read an optional list of strings from the platform configuration
+func getConfigStrings(platformConfig map[string]interface{}, key string) []string {
+	values, ok := platformConfig[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	return convertToSliceString(values)
+}
+
+// Read an optional string from the platform configuration
+func getConfigString(platformConfig map[string]interface{}, key string) string {
+	value, _ := platformConfig[key].(string)
+	return value
+}
+
+// Read an optional number from the platform configuration
+func getConfigInt(platformConfig map[string]interface{}, key string) int {
+	value, ok := platformConfig[key].(float64)
+	if !ok {
+		return 0
+	}
+	return int(value)
+}
+
+// Set the binary, minified and generated files detection, the strict mode,
+// the .gitignore support, the path exclusion patterns and profiles, the
+// test file patterns and the in-memory clones
+func setScanOptions(params *goloc.Params, platformConfig map[string]interface{}) {
+	params.SkipBinary = getConfigBool(platformConfig, "SkipBinary")
+	params.SkipMinified = getConfigBool(platformConfig, "SkipMinified")
+	params.SkipGenerated = getConfigBool(platformConfig, "SkipGenerated")
+	params.MinifiedLineLength = getConfigInt(platformConfig, "MinifiedLineLength")
+	params.GeneratedMarkers = getConfigStrings(platformConfig, "GeneratedMarkers")
+	params.Strict = getConfigBool(platformConfig, "Strict")
+	params.GitIgnore = getConfigBool(platformConfig, "GitIgnore")
+	params.ExcludePatterns = getConfigStrings(platformConfig, "PathExclusion")
+	params.ExcludeFile = getConfigString(platformConfig, "PathExclusionFile")
+	params.ExclusionProfiles = getExclusionProfiles(platformConfig)
+	params.TestPatterns = getConfigStrings(platformConfig, "TestPatterns")
+	params.InMemory = getConfigBool(platformConfig, "InMemoryClone")
+}
+
+// Select how the progress of the analyses is shown : a spinner on the
+// terminal, nothing, or a JSON event per line on stderr for CI logs
+func newObserver(name string) (events.Observer, error) {
+	switch strings.ToLower(name) {
+	case "tty":
+		return events.NewTTY(os.Stdout), nil
+	case "quiet":
+		return events.Quiet{}, nil
+	case "json":
+		return events.NewJSONLines(os.Stderr), nil
+	}
+	return nil, fmt.Errorf("unknown -events output <%s> : <tty>||<quiet>||<json>", name)
+}
+
+// Resolve the exclusion profiles enabled by ExclusionProfiles, the patterns
+// of ExclusionProfilePatterns come after the built-in ones
+func getExclusionProfiles(platformConfig map[string]interface{}) map[string][]string {
+	names := getConfigStrings(platformConfig, "ExclusionProfiles")
+	if len(names) == 0 {
+		return nil
+	}
+	extraPatterns, _ := platformConfig["ExclusionProfilePatterns"].(map[string]interface{})
+
+	profiles := make(map[string][]string)
+	for _, name := range names {
+		patterns := append([]string{}, assets.ExclusionProfiles[name]...)
+		if values, ok := extraPatterns[name].([]interface{}); ok {
+			patterns = append(patterns, convertToSliceString(values)...)
+		}
+		if len(patterns) == 0 {
+			logger.Warnf("⚠️ Unknown exclusion profile <%s>", name)
+			continue
+		}
+		profiles[name] = patterns
+	}
+
+	return profiles
+}
+
+// Load the languages file given by the -languages-file flag or the config file
+func loadLanguages(path string) error {
+	if path == "" {
+		path = AppConfig.LanguagesFile
+	}
+	if path == "" {
+		return nil
 	}
 
-	var report Report
-	err = json.Unmarshal(file, &report)
+	languages, origins, err := language.LoadFile(path, assets.Languages)
 	if err != nil {
-		//fmt.Println("❌ Error parsing JSON:", err)
-		logger.Errorf("❌ Error parsing JSON:", err)
+		return err
 	}
 
-	return report.TotalCodeLines
+	customLanguages = languages
+	languageOrigins = origins
+	return nil
 }
 
-// convert To Slice String
-func convertToSliceString(in []interface{}) []string {
-	out := make([]string, len(in))
-	for i, v := range in {
-		out[i] = v.(string)
+// Record a repository or directory whose analysis failed for the global report
+func addRepoFailure(project, repo string, err error) {
+	repoResultsMutex.Lock()
+	defer repoResultsMutex.Unlock()
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		logger.Errorf("⏱️ The analysis of <%s> timed out", repo)
 	}
-	return out
+	failedRepos = append(failedRepos, FailedRepo{
+		Project: project,
+		Repo:    repo,
+		Error:   err.Error(),
+	})
+}
+
+// Create the context of the analysis of one repository, limited to
+// RepoTimeout seconds when it is set
+func repoContext(platformConfig map[string]interface{}) (context.Context, context.CancelFunc) {
+	if timeout := getConfigInt(platformConfig, "RepoTimeout"); timeout > 0 {
+		return context.WithTimeout(appContext, time.Duration(timeout)*time.Second)
+	}
+	return context.WithCancel(appContext)
+}
+
+// Create a GCloc using the languages merged from the languages file
+func newGCloc(ctx context.Context, params goloc.Params) (*goloc.GCloc, error) {
+	languages := assets.Languages
+	if customLanguages != nil {
+		languages = customLanguages
+	}
+
+	return goloc.NewGCloc(ctx, params, languages)
 }
 
 // Create a Bakup File for Result directory
@@ -267,15 +401,10 @@ func addFileToZip(filePath, relPath string, fileInfo os.FileInfo, zipWriter *zip
 }
 
 // Generic function to analyze repositories
-func AnalyseReposList(DestinationResult string, platformConfig map[string]interface{}, repolist interface{}, analyseRepoFunc func(project interface{}, DestinationResult string, platformConfig map[string]interface{}, spin *spinner.Spinner, results chan int, count *int)) (cpt int) {
+func AnalyseReposList(DestinationResult string, platformConfig map[string]interface{}, repolist interface{}, analyseRepoFunc func(project interface{}, DestinationResult string, platformConfig map[string]interface{}, results chan int, count *int)) (cpt int) {
 	//fmt.Print("\n🔎 Analysis of Repos ...\n")
 	logger.Infof("🔎 Analysis of Repos ...\n")
 
-	spin := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin.Color("green", "bold")
-	messageF := ""
-	spin.FinalMSG = messageF
-
 	// Create a channel to receive results
 	results := make(chan int)
 	count := 1
@@ -288,19 +417,19 @@ func AnalyseReposList(DestinationResult string, platformConfig map[string]interf
 			remainder := len(repolist.([]interface{})) % X
 			for i := 0; i < batches; i++ {
 				for j := i * X; j < (i+1)*X; j++ {
-					go analyseRepoFunc(repolist.([]interface{})[j], DestinationResult, platformConfig, spin, results, &count)
+					go analyseRepoFunc(repolist.([]interface{})[j], DestinationResult, platformConfig, results, &count)
 				}
 				waitForWorkers(X, results)
 			}
 			// Launch remaining goroutines
 			for i := batches * X; i < batches*X+remainder; i++ {
-				go analyseRepoFunc(repolist.([]interface{})[i], DestinationResult, platformConfig, spin, results, &count)
+				go analyseRepoFunc(repolist.([]interface{})[i], DestinationResult, platformConfig, results, &count)
 			}
 			waitForWorkers(remainder, results)
 		} else {
 			// Launch goroutines for each repo
 			for _, project := range repolist.([]interface{}) {
-				go analyseRepoFunc(project, DestinationResult, platformConfig, spin, results, &count)
+				go analyseRepoFunc(project, DestinationResult, platformConfig, results, &count)
 			}
 			waitForWorkers(len(repolist.([]interface{})), results)
 		}
@@ -308,7 +437,7 @@ func AnalyseReposList(DestinationResult string, platformConfig map[string]interf
 		// Without multithreading
 		for _, project := range repolist.([]interface{}) {
 			// Execute the analysis synchronously
-			analyseRepoFunc(project, DestinationResult, platformConfig, spin, results, &count)
+			analyseRepoFunc(project, DestinationResult, platformConfig, results, &count)
 		}
 	}
 
@@ -318,11 +447,8 @@ func AnalyseReposList(DestinationResult string, platformConfig map[string]interf
 // Analysis functions for different repository types
 
 // Analysis functions for Bitbucket Cloud
-func analyseBitCRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, spin *spinner.Spinner, results chan int, count *int) {
+func analyseBitCRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, results chan int, count *int) {
 	p := project.(getbibucket.ProjectBranch)
-	var excludeExtensions []string
-	excludeExtensions = convertToSliceString(platformConfig["ExtExclusion"].([]interface{}))
-
 	params := RepoParams{
 		ProjectKey: p.ProjectKey,
 		Namespace:  "",
@@ -330,15 +456,12 @@ func analyseBitCRepo(project interface{}, DestinationResult string, platformConf
 		MainBranch: p.MainBranch,
 		PathToScan: fmt.Sprintf("%s://x-token-auth:%s@%s/%s/%s.git", platformConfig["Protocol"].(string), platformConfig["AccessToken"].(string), platformConfig["Baseapi"].(string), platformConfig["Workspace"].(string), p.RepoSlug),
 	}
-	performRepoAnalysis(params, DestinationResult, spin, results, count, excludeExtensions)
+	performRepoAnalysis(params, DestinationResult, results, count, platformConfig)
 }
 
 // Analysis functions for Bitbucket DC
-func analyseBitSRVRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, trimmedURL string, spin *spinner.Spinner, results chan int, count *int) {
+func analyseBitSRVRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, trimmedURL string, results chan int, count *int) {
 	p := project.(getbibucketdc.ProjectBranch)
-	var excludeExtensions []string
-	excludeExtensions = convertToSliceString(platformConfig["ExtExclusion"].([]interface{}))
-
 	params := RepoParams{
 		ProjectKey: p.ProjectKey,
 		Namespace:  "",
@@ -346,16 +469,13 @@ func analyseBitSRVRepo(project interface{}, DestinationResult string, platformCo
 		MainBranch: p.MainBranch,
 		PathToScan: fmt.Sprintf("%s://%s:%s@%sscm/%s/%s.git", platformConfig["Protocol"].(string), platformConfig["Users"].(string), platformConfig["AccessToken"].(string), trimmedURL, p.ProjectKey, p.RepoSlug),
 	}
-	performRepoAnalysis(params, DestinationResult, spin, results, count, excludeExtensions)
+	performRepoAnalysis(params, DestinationResult, results, count, platformConfig)
 }
 
 // Analysis functions for GitHub
-func analyseGithubRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, spin *spinner.Spinner, results chan int, count *int) {
+func analyseGithubRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, results chan int, count *int) {
 	p := project.(getgithub.ProjectBranch)
 
-	var excludeExtensions []string
-	excludeExtensions = convertToSliceString(platformConfig["ExtExclusion"].([]interface{}))
-
 	params := RepoParams{
 		ProjectKey: p.Org,
 		Namespace:  "",
@@ -363,15 +483,12 @@ func analyseGithubRepo(project interface{}, DestinationResult string, platformCo
 		MainBranch: p.MainBranch,
 		PathToScan: fmt.Sprintf("%s://%s:x-oauth-basic@%s/%s/%s.git", platformConfig["Protocol"].(string), platformConfig["AccessToken"].(string), platformConfig["Baseapi"].(string), p.Org, p.RepoSlug),
 	}
-	performRepoAnalysis(params, DestinationResult, spin, results, count, excludeExtensions)
+	performRepoAnalysis(params, DestinationResult, results, count, platformConfig)
 }
 
 // Analysis functions for GitLab
-func analyseGitlabRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, spin *spinner.Spinner, results chan int, count *int) {
+func analyseGitlabRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, results chan int, count *int) {
 	p := project.(getgitlab.ProjectBranch)
-	var excludeExtensions []string
-	excludeExtensions = convertToSliceString(platformConfig["ExtExclusion"].([]interface{}))
-
 	params := RepoParams{
 		ProjectKey: p.Org,
 		Namespace:  p.Namespace,
@@ -379,14 +496,11 @@ func analyseGitlabRepo(project interface{}, DestinationResult string, platformCo
 		MainBranch: p.MainBranch,
 		PathToScan: fmt.Sprintf("%s://gitlab-ci-token:%s@%s/%s.git", platformConfig["Protocol"].(string), platformConfig["AccessToken"].(string), "gitlab.com", p.Namespace),
 	}
-	performRepoAnalysis(params, DestinationResult, spin, results, count, excludeExtensions)
+	performRepoAnalysis(params, DestinationResult, results, count, platformConfig)
 }
 
-func analyseAzurebRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, spin *spinner.Spinner, results chan int, count *int) {
+func analyseAzurebRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, results chan int, count *int) {
 	p := project.(getazure.ProjectBranch)
-	var excludeExtensions []string
-	excludeExtensions = convertToSliceString(platformConfig["ExtExclusion"].([]interface{}))
-
 	params := RepoParams{
 		ProjectKey: p.ProjectKey,
 		Namespace:  "",
@@ -394,12 +508,13 @@ func analyseAzurebRepo(project interface{}, DestinationResult string, platformCo
 		MainBranch: p.MainBranch,
 		PathToScan: fmt.Sprintf("%s://%s@%s/%s/%s/%s/%s", platformConfig["Protocol"].(string), platformConfig["AccessToken"].(string), "dev.azure.com", platformConfig["Organization"].(string), p.ProjectKey, "_git", p.RepoSlug),
 	}
-	performRepoAnalysis(params, DestinationResult, spin, results, count, excludeExtensions)
+	performRepoAnalysis(params, DestinationResult, results, count, platformConfig)
 }
 
 // Perform repository analysis (common logic)
-func performRepoAnalysis(params RepoParams, DestinationResult string, spin *spinner.Spinner, results chan int, count *int, excludeExtension []string) {
+func performRepoAnalysis(params RepoParams, DestinationResult string, results chan int, count *int, platformConfig map[string]interface{}) {
 	var outputFileName = ""
+	excludeExtension := convertToSliceString(platformConfig["ExtExclusion"].([]interface{}))
 
 	if len(params.Namespace) > 0 {
 		outputFileName = fmt.Sprintf("Result_%s_%s", params.Namespace, params.MainBranch)
@@ -424,20 +539,31 @@ func performRepoAnalysis(params RepoParams, DestinationResult string, spin *spin
 		OutputPath:        DestinationResult,
 		ReportFormats:     []string{"json"},
 		Branch:            params.MainBranch,
+		Name:              params.RepoSlug,
+		Logger:            logger,
 	}
-	MessB := fmt.Sprintf("   Extracting files from repo : %s ", params.RepoSlug)
-	spin.Suffix = MessB
-	spin.Start()
+	setScanOptions(&golocParams, platformConfig)
 
-	gc, err := goloc.NewGCloc(golocParams, assets.Languages)
+	ctx, cancel := repoContext(platformConfig)
+	defer cancel()
+
+	gc, err := newGCloc(ctx, golocParams)
 	if err != nil {
-		logger.Errorf(errorMessageRepo, err)
+		logger.Errorf(errorMessageRepo+"%v", err)
+		addRepoFailure(params.ProjectKey, params.RepoSlug, err)
 		*count++
 		results <- 1
 		return
 	} else {
+		defer gc.Close()
 
-		gc.Run()
+		summary, err := gc.Run(ctx)
+		if err != nil {
+			logger.Errorf(errorMessageRepo+"%v", err)
+			addRepoFailure(params.ProjectKey, params.RepoSlug, err)
+		} else {
+			addRepoResult(params.ProjectKey, params.RepoSlug, summary)
+		}
 		*count++
 
 		// Remove Repository Directory
@@ -446,7 +572,6 @@ func performRepoAnalysis(params RepoParams, DestinationResult string, spin *spin
 			logger.Errorf(errorMessageDi, err1)
 		}
 
-		spin.Stop()
 		logger.Infof("\r\t\t\t\t✅ %d The repository <%s> has been analyzed\n", *count, params.RepoSlug)
 		// Send result through channel
 		results <- 1
@@ -480,8 +605,8 @@ func AnalyseReposListBitSRV(DestinationResult string, platformConfig map[string]
 	for i, v := range repolist {
 		repoInterfaces[i] = v
 	}
-	return AnalyseReposList(DestinationResult, platformConfig, repoInterfaces, func(project interface{}, DestinationResult string, platformConfig map[string]interface{}, spin *spinner.Spinner, results chan int, count *int) {
-		analyseBitSRVRepo(project, DestinationResult, platformConfig, trimmedURL, spin, results, count)
+	return AnalyseReposList(DestinationResult, platformConfig, repoInterfaces, func(project interface{}, DestinationResult string, platformConfig map[string]interface{}, results chan int, count *int) {
+		analyseBitSRVRepo(project, DestinationResult, platformConfig, trimmedURL, results, count)
 	})
 }
 
@@ -514,7 +639,7 @@ func AnalyseReposListAzure(DestinationResult string, platformConfig map[string]i
 
 /* ---------------- Analyse Directory ---------------- */
 
-func AnalyseReposListFile(Listdirectorie, fileexclusionEX []string, extexclusion []string) {
+func AnalyseReposListFile(Listdirectorie, fileexclusionEX []string, extexclusion []string, platformConfig map[string]interface{}) {
 
 	type Configuration struct {
 		ExcludeExtensions []string
@@ -533,11 +658,6 @@ func AnalyseReposListFile(Listdirectorie, fileexclusionEX []string, extexclusion
 
 			//fmt.Println("Rep:", Listdirectories)
 
-			spin := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-			spin.Color("green", "bold")
-			messageF := ""
-			spin.FinalMSG = messageF
-
 			outputFileName := "Result_"
 
 			params := goloc.Params{
@@ -558,17 +678,29 @@ func AnalyseReposListFile(Listdirectorie, fileexclusionEX []string, extexclusion
 				ReportFormats:     []string{"json"},
 				Branch:            "",
 				Token:             "",
+				Logger:            logger,
 			}
+			setScanOptions(&params, platformConfig)
 
-			gc, err := goloc.NewGCloc(params, assets.Languages)
+			ctx, cancel := repoContext(platformConfig)
+			defer cancel()
+
+			gc, err := newGCloc(ctx, params)
 			if err != nil {
 				//fmt.Println(errorMessageRepo, err)
-				logger.Errorf(errorMessageRepo, err)
+				logger.Errorf(errorMessageRepo+"%v", err)
+				addRepoFailure("", dir, err)
 				return
 			}
+			defer gc.Close()
 
-			gc.Run()
-			spin.Stop()
+			summary, err := gc.Run(ctx)
+			if err != nil {
+				logger.Errorf(errorMessageRepo+"%v", err)
+				addRepoFailure("", dir, err)
+			} else {
+				addRepoResult("", dir, summary)
+			}
 			//	fmt.Printf("\r\t✅ %d The directory <%s> has been analyzed\n", count, dir)
 			logger.Infof("\t✅ %d The directory <%s> has been analyzed\n", count, dir)
 			count++
@@ -582,13 +714,17 @@ func AnalyseReposListFile(Listdirectorie, fileexclusionEX []string, extexclusion
 /* ---------------- End Analyse Directory ---------------- */
 
 func AnalyseRun(params goloc.Params, reponame string) {
-	gc, err := goloc.NewGCloc(params, assets.Languages)
+	gc, err := newGCloc(appContext, params)
 	if err != nil {
 		fmt.Println(errorMessageRepo, err)
 		os.Exit(1)
 	}
+	defer gc.Close()
 
-	gc.Run()
+	if _, err := gc.Run(appContext); err != nil {
+		fmt.Println(errorMessageRepo, err)
+		os.Exit(1)
+	}
 }
 
 func AnalyseRepo(DestinationResult string, Users string, AccessToken string, DevOps string, Organization string, reponame string) (cpt int) {
@@ -614,13 +750,15 @@ func AnalyseRepo(DestinationResult string, Users string, AccessToken string, Dev
 		OutputPath:        DestinationResult,
 		ReportFormats:     []string{"json"},
 	}
-	gc, err := goloc.NewGCloc(params, assets.Languages)
+	gc, err := newGCloc(appContext, params)
 	if err != nil {
 		fmt.Println(errorMessageRepo, err)
 		os.Exit(1)
 	}
 
-	gc.Run()
+	if _, err := gc.Run(appContext); err != nil {
+		fmt.Println(errorMessageRepo, err)
+	}
 	cpt++
 
 	// Remove Repository Directory
@@ -658,22 +796,32 @@ func ReadLines(filename string) ([]string, error) {
 }
 
 func displayLanguages() {
-	fmt.Printf("%-18s | %-78s | %-15s | %s\n", "Language", "Extensions", "Single Comments", "Multi Line Comments")
-	fmt.Println("-------------------+--------------------------------------------------------------------------------+-----------------+--------------------")
+	languages := assets.Languages
+	if customLanguages != nil {
+		languages = customLanguages
+	}
+
+	fmt.Printf("%-18s | %-78s | %-15s | %-20s | %s\n", "Language", "Extensions", "Single Comments", "Multi Line Comments", "Origin")
+	fmt.Println("-------------------+--------------------------------------------------------------------------------+-----------------+----------------------+--------------------")
 
-	for lang, config := range assets.Languages {
-		extensions := strings.Join(config.Extensions, ", ") // Concatenate extensions with comma separator
+	for _, lang := range languages.Names() {
+		config := languages[lang]
+		names := append(append([]string{}, config.Extensions...), config.Filenames...)
+		extensions := strings.Join(names, ", ") // Concatenate extensions and file names with comma separator
 
 		singleComments := strings.Join(config.LineComments, ", ") // Concatenate single comments with comma separator
 
 		multiLineComments := ""
 		for _, comments := range config.MultiLineComments {
-			for _, comment := range comments {
-				multiLineComments += comment + " "
-			}
+			multiLineComments += comments[0] + " " + comments[1] + " "
 		}
 
-		fmt.Printf("%-18s | %-78s | %-15s | %s\n", lang, extensions, singleComments, multiLineComments)
+		origin := language.OriginBuiltin
+		if languageOrigins != nil {
+			origin = languageOrigins[lang]
+		}
+
+		fmt.Printf("%-18s | %-78s | %-15s | %-20s | %s\n", lang, extensions, singleComments, multiLineComments, origin)
 	}
 }
 
@@ -723,8 +871,10 @@ func main() {
 	fastFlag := flag.Bool("fast", false, "Enable fast mode (only for Github)")
 	helpFlag := flag.Bool("help", false, "Show help message")
 	languagesFlag := flag.Bool("languages", false, "Show all supported languages")
+	languagesFileFlag := flag.String("languages-file", "", "JSON file adding or overriding language definitions")
 	versionflag := flag.Bool("version", false, "Show version")
 	docker := flag.Bool("docker", false, "Run in Docker mode")
+	eventsFlag := flag.String("events", "tty", "Progress output : <tty>||<quiet>||<json>")
 
 	flag.Parse()
 
@@ -735,6 +885,11 @@ func main() {
 		os.Exit(0)
 	}
 
+	if err := loadLanguages(*languagesFileFlag); err != nil {
+		fmt.Printf("\n❌ Failed to load languages file: %s\n", err)
+		os.Exit(1)
+	}
+
 	if *languagesFlag {
 		displayLanguages()
 		os.Exit(0)
@@ -745,6 +900,18 @@ func main() {
 		os.Exit(0)
 	}
 
+	observer, err := newObserver(*eventsFlag)
+	if err != nil {
+		fmt.Printf("\n❌ %s\n", err)
+		os.Exit(1)
+	}
+	events.SetObserver(observer)
+
+	// Ctrl-C cancels the analyses in progress, which remove their clones
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	appContext = ctx
+
 	if *devopsFlag == "" {
 		fmt.Println("\n❌ Please specify the DevOps platform using the -devops flag : <BitBucketSRV>||<BitBucket>||<Github>||<Gitlab>||<Azure>||<File>")
 		fmt.Println("✅ Example for BitBucket server : golc -devops BitBucketSRV")
@@ -1007,75 +1174,49 @@ func main() {
 			}
 		}
 		startTime = time.Now()
-		AnalyseReposListFile(ListDirectory, ListExclusion, excludeExtensions)
+		AnalyseReposListFile(ListDirectory, ListExclusion, excludeExtensions, platformConfig)
 	}
 
 	/*---------------------------------- End Select type of DevOps Platform ----------------------------------------------------*/
 
+	if appContext.Err() != nil {
+		logger.Errorf("❌ Analysis interrupted")
+		os.Exit(1)
+	}
+	if len(failedRepos) > 0 {
+		logger.Warnf("⚠️ The analysis of %d repositories failed, they are listed in the Global Report", len(failedRepos))
+	}
+
 	// Begin of report file analysis
 	//fmt.Print("\n🔎 Analyse Report ...\n")
 
 	fmt.Printf("\n")
 	logger.Infof("🔎 Analyse Report ...\n")
-	spin := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin.Suffix = " Analyse Report..."
-	spin.Color("green", "bold")
-	spin.Start()
-
-	// List files in the directory
-	files, err := os.ReadDir(DestinationResult)
-	if err != nil {
-		logger.Errorf("❌ Error listing files:%v", err)
-		os.Exit(1)
-	}
+	events.Start("Analyse Report...")
 
 	// Initialize the sum of TotalCodeLines
 	totalCodeLinesSum := 0
 
-	// Analyse All file
-	for _, file := range files {
-		// Check if the file is a JSON file
-		if !file.IsDir() && strings.HasSuffix(file.Name(), ".json") {
-			// Read contents of JSON file
-			filePath := filepath.Join(DestinationResult, file.Name())
-			jsonData, err := os.ReadFile(filePath)
-			if err != nil {
-				logger.Errorf("❌ Error reading file %s: %v\n", file.Name(), err)
-				continue
-			}
-
-			// Parse JSON content into a Result structure
-			var result Result
-			err = json.Unmarshal(jsonData, &result)
-			if err != nil {
-				logger.Errorf("❌ Error parsing JSON contents of file %s: %v\n", file.Name(), err)
-				continue
-			}
+	// Analyse the results of all repositories
+	for _, result := range repoResults {
+		totalCodeLinesSum += result.CodeLines
 
-			totalCodeLinesSum += result.TotalCodeLines
-
-			// Check if this repo has a higher TotalCodeLines than the current maximum
-			if result.TotalCodeLines > maxTotalCodeLines {
-				maxTotalCodeLines = result.TotalCodeLines
-				// Extract project and repo name from file name
-				parts := strings.Split(strings.TrimSuffix(file.Name(), ".json"), "_")
-				if platformConfig["DevOps"].(string) != "file" {
-					maxProject = parts[1]
-					maxRepo = parts[2]
-				} else {
-					maxProject = ""
-					maxRepo = parts[1]
-					NumberRepos++
-				}
-			}
+		// Check if this repo has a higher TotalCodeLines than the current maximum
+		if result.CodeLines > maxTotalCodeLines {
+			maxTotalCodeLines = result.CodeLines
+			maxProject = result.Project
+			maxRepo = result.Repo
 		}
-
 	}
+	if platformConfig["DevOps"].(string) == "file" {
+		NumberRepos = len(repoResults)
+	}
+
 	maxTotalCodeLines1 := utils.FormatCodeLines(float64(maxTotalCodeLines))
 	totalCodeLinesSum1 := utils.FormatCodeLines(float64(totalCodeLinesSum))
 
 	if totalCodeLinesSum1 == "0" {
-		spin.Stop()
+		events.Finish("")
 		fmt.Println("\n --------------------------------------------------------------------")
 		logger.Error("  ❌ There is definitely a problem, 0 lines of code are reported ???")
 		fmt.Println("\n --------------------------------------------------------------------")
@@ -1090,6 +1231,7 @@ func main() {
 		LinesOfCodeLargestRepo: maxTotalCodeLines1,
 		DevOpsPlatform:         platformConfig["DevOps"].(string),
 		NumberRepos:            NumberRepos,
+		FailedRepositories:     failedRepos,
 	}
 
 	jsonData, err := json.MarshalIndent(data, "", "    ")
@@ -1111,7 +1253,7 @@ func main() {
 		return
 	}
 
-	spin.Stop()
+	events.Finish("")
 
 	endTime := time.Now()
 	duration := endTime.Sub(startTime)
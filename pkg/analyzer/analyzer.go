@@ -1,69 +1,228 @@
 package analyzer
 
 import (
+	"context"
 	"io/fs"
 	"path/filepath"
-	"strings"
+
+	"github.com/colussim/GoLC/pkg/filesystem"
+	"github.com/colussim/GoLC/pkg/goloc/language"
+	"github.com/sirupsen/logrus"
 )
 
 type Analyzer struct {
-	SupportedExtensions map[string]string
+	SupportedExtensions map[string][]string
+	SupportedFilenames  map[string]string
+	SupportedShebangs   map[string]string
+	SkippedFiles        []SkippedFile
+	Errors              []FileError
+	Profiles            []Profile
+	TestPatterns        []string
+	Excluded            []ProfileExclusion
+	Strict              bool
+	GitIgnore           bool
+	Logger              *logrus.Logger
+	Source              *filesystem.Source
 	path                string
-	excludePaths        []string
+	exclude             *filesystem.Matcher
 	excludeExtensions   map[string]bool
 	includeExtensions   map[string]bool
+	contentFilter       ContentFilter
+	heuristics          map[string][]compiledHeuristic
+	filenamePatterns    []string
+	siblings            map[string]map[string]bool
+	notebooks           map[string]bool
+	tests               map[string][]string
+	testMatchers        map[string]*filesystem.Matcher
+	testPatterns        *filesystem.Matcher
 }
 
 type FileMetadata struct {
 	FilePath  string
 	Extension string
 	Language  string
+	IsTest    bool
+}
+
+// FileError is a file or directory that could not be read. Unless the
+// analysis is Strict, it is recorded and left out instead of failing.
+type FileError struct {
+	FilePath string
+	Err      error
 }
 
 func NewAnalyzer(
 	path string,
-	excludePaths []string,
+	exclude *filesystem.Matcher,
 	excludeExtensions map[string]bool,
 	includeExtensions map[string]bool,
-	extensions map[string]string,
-) *Analyzer {
-	return &Analyzer{
-		SupportedExtensions: extensions,
-		path:                path,
-		excludePaths:        excludePaths,
-		excludeExtensions:   excludeExtensions,
-		includeExtensions:   includeExtensions,
+	languages language.Languages,
+	contentFilter ContentFilter,
+) (*Analyzer, error) {
+	analyzer := &Analyzer{
+		path:              path,
+		exclude:           exclude,
+		excludeExtensions: excludeExtensions,
+		includeExtensions: includeExtensions,
+		contentFilter:     contentFilter,
 	}
+
+	if err := analyzer.SetLanguages(languages); err != nil {
+		return nil, err
+	}
+
+	return analyzer, nil
 }
 
-func (a *Analyzer) MatchingFiles() ([]FileMetadata, error) {
+// MatchingFiles returns the files to scan. Files left out by the content
+// filter are recorded in SkippedFiles with the reason why, and unreadable
+// files in Errors. With GitIgnore, the files ignored by git are left out.
+// The files removed by each of the Profiles are counted in Excluded, and
+// test files are flagged with IsTest. The walk stops when ctx is done.
+func (a *Analyzer) MatchingFiles(ctx context.Context) ([]FileMetadata, error) {
 	var files []FileMetadata
+	a.SkippedFiles = nil
+	a.Errors = nil
+	a.testMatchers = map[string]*filesystem.Matcher{}
+	testPatterns, err := filesystem.NewMatcher(a.path, a.TestPatterns)
+	if err != nil {
+		return nil, err
+	}
+	a.testPatterns = testPatterns
+	a.Excluded = make([]ProfileExclusion, len(a.Profiles))
+	for i, profile := range a.Profiles {
+		a.Excluded[i].Profile = profile.Name
+	}
+	a.siblings = nil
 
-	err := filepath.Walk(a.path, func(path string, info fs.FileInfo, err error) error {
-		if err != nil {
+	var ignore *gitIgnore
+	if a.GitIgnore {
+		ignore = newGitIgnore(a.source())
+	}
+
+	err = a.source().WalkDir(func(path string, entry fs.DirEntry, err error) error {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
+		if err != nil {
+			return a.fileError(path, err)
+		}
+
+		// The .git directory, or the .git file of a worktree, is never
+		// part of the sources.
+		if entry.Name() == gitDir && path != a.path {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if ignore != nil && ignore.ignored(path, entry.IsDir()) {
+			if a.Logger != nil {
+				a.Logger.Debugf("🙈 %s ignored by .gitignore", path)
+			}
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 
-		if info.IsDir() {
+		if entry.IsDir() {
+			if a.exclude != nil && path != a.path && a.exclude.SkipDir(path) {
+				return filepath.SkipDir
+			}
+			if path != a.path {
+				if pruned, err := a.pruneByProfile(ctx, path); pruned {
+					if err != nil {
+						return err
+					}
+					return filepath.SkipDir
+				}
+			}
+			if ignore != nil {
+				ignore.enterDir(path)
+			}
 			return nil
 		}
 
 		fileExtension := a.getFileExtension(path)
-		if a.canAdd(path, fileExtension) {
-			fm := FileMetadata{
-				FilePath:  path,
-				Extension: fileExtension,
-				Language:  a.SupportedExtensions[fileExtension],
+		if !a.canAdd(path, fileExtension) || a.excludeByProfile(path) {
+			return nil
+		}
+
+		language, err := a.detectLanguage(path)
+		if err != nil {
+			return a.fileError(path, err)
+		}
+		if language == "" {
+			return nil
+		}
+
+		if a.contentFilter.enabled() {
+			filter := a.contentFilter
+			if a.notebooks[language] {
+				// Notebooks keep the outputs of their cells on long lines.
+				filter.SkipMinified = false
+			}
+			reason, err := filter.sniff(a.source(), path)
+			if err != nil {
+				return a.fileError(path, err)
+			}
+			if reason != "" {
+				a.SkippedFiles = append(a.SkippedFiles, SkippedFile{
+					FilePath: path,
+					Reason:   reason,
+				})
+				return nil
 			}
-			files = append(files, fm)
 		}
 
+		isTest, err := a.isTest(path, language)
+		if err != nil {
+			return a.fileError(path, err)
+		}
+
+		fm := FileMetadata{
+			FilePath:  path,
+			Extension: fileExtension,
+			Language:  language,
+			IsTest:    isTest,
+		}
+		files = append(files, fm)
+
 		return nil
 	})
 
 	return files, err
 }
 
+// source returns the Source of the files, the directory on disk by default.
+func (a *Analyzer) source() *filesystem.Source {
+	if a.Source == nil {
+		return filesystem.DirSource(a.path)
+	}
+
+	return a.Source
+}
+
+// fileError fails the walk in strict mode or for the root directory, and
+// otherwise records the error and goes on with the next file.
+func (a *Analyzer) fileError(path string, err error) error {
+	if a.Strict || path == a.path {
+		return err
+	}
+
+	a.Errors = append(a.Errors, FileError{
+		FilePath: path,
+		Err:      err,
+	})
+	if a.Logger != nil {
+		a.Logger.Warnf("⚠️ %s skipped: %v", path, err)
+	}
+
+	return nil
+}
+
 func (a *Analyzer) getFileExtension(path string) string {
 	extension := filepath.Ext(path)
 
@@ -75,21 +234,15 @@ func (a *Analyzer) getFileExtension(path string) string {
 }
 
 func (a *Analyzer) canAdd(path string, extension string) bool {
-	for _, pathToExclude := range a.excludePaths {
-		if strings.HasPrefix(path, pathToExclude) {
-			return false
-		}
+	if a.exclude != nil && a.exclude.Match(path, false) {
+		return false
 	}
 
 	if len(a.includeExtensions) > 0 {
-		_, ok := a.includeExtensions[a.getFileExtension(path)]
+		_, ok := a.includeExtensions[extension]
 		return ok
 	}
 
-	if _, ok := a.excludeExtensions[a.getFileExtension(path)]; ok {
-		return false
-	}
-
-	_, ok := a.SupportedExtensions[extension]
-	return ok
+	_, ok := a.excludeExtensions[extension]
+	return !ok
 }
@@ -0,0 +1,115 @@
+package analyzer
+
+import (
+	"bufio"
+	"path/filepath"
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/filesystem"
+)
+
+const shebangLength = 256
+
+// detectLanguage finds the language of a file by its exact file name,
+// then by its extension and, for files without an extension, by the
+// interpreter of its #! line. Extensions shared by several languages are
+// disambiguated by their heuristics.
+func (a *Analyzer) detectLanguage(path string) (string, error) {
+	base := filepath.Base(path)
+
+	if language := a.matchFilename(base); language != "" {
+		return language, nil
+	}
+
+	extension := a.getFileExtension(path)
+	if candidates, ok := a.SupportedExtensions[extension]; ok {
+		return a.disambiguate(path, extension, candidates)
+	}
+
+	if filepath.Ext(path) != "" || len(a.SupportedShebangs) == 0 {
+		return "", nil
+	}
+
+	interpreter, err := readShebang(a.source(), path)
+	if err != nil || interpreter == "" {
+		return "", err
+	}
+
+	return a.matchShebang(interpreter), nil
+}
+
+func (a *Analyzer) matchFilename(base string) string {
+	if language, ok := a.SupportedFilenames[base]; ok {
+		return language
+	}
+
+	// The patterns are sorted, so that the first one matching is always
+	// the same.
+	for _, pattern := range a.filenamePatterns {
+		if ok, _ := filepath.Match(pattern, base); ok {
+			return a.SupportedFilenames[pattern]
+		}
+	}
+
+	return ""
+}
+
+// matchShebang looks the interpreter up as is, then without its version
+// suffix (python3.11 -> python3 -> python).
+func (a *Analyzer) matchShebang(interpreter string) string {
+	for interpreter != "" {
+		if language, ok := a.SupportedShebangs[interpreter]; ok {
+			return language
+		}
+		trimmed := strings.TrimRight(interpreter, "0123456789.")
+		if trimmed == interpreter {
+			break
+		}
+		interpreter = trimmed
+	}
+
+	return ""
+}
+
+// readShebang returns the interpreter named by the #! line of the file,
+// resolving /usr/bin/env indirections.
+func readShebang(source *filesystem.Source, path string) (string, error) {
+	f, err := source.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReaderSize(f, shebangLength)
+	line, err := reader.Peek(shebangLength)
+	if len(line) < 2 {
+		return "", nil
+	}
+	if string(line[:2]) != "#!" {
+		return "", nil
+	}
+	if end := strings.IndexAny(string(line), "\r\n"); end >= 0 {
+		line = line[:end]
+	} else if err == nil {
+		return "", nil
+	}
+
+	fields := strings.Fields(string(line[2:]))
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" {
+		interpreter = ""
+		for _, field := range fields[1:] {
+			if strings.HasPrefix(field, "-") || strings.Contains(field, "=") {
+				continue
+			}
+			interpreter = filepath.Base(field)
+			break
+		}
+	}
+
+	return interpreter, nil
+}
@@ -0,0 +1,96 @@
+package analyzer
+
+import (
+	"path"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/colussim/GoLC/pkg/filesystem"
+	"github.com/colussim/GoLC/pkg/goloc/language"
+)
+
+var detectLanguages = language.Languages{
+	"Dockerfile": {Filenames: []string{"Dockerfile", "Dockerfile.*", "*.dockerfile"}},
+	"Makefile":   {Extensions: []string{".mk"}, Filenames: []string{"Makefile", "GNUmakefile"}},
+	"Python":     {Extensions: []string{".py"}, Shebangs: []string{"python"}},
+	"Shell":      {Extensions: []string{".sh"}, Shebangs: []string{"sh", "bash"}},
+	"Text":       {Extensions: []string{".txt"}, Filenames: []string{"CMakeLists.txt"}},
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{name: "exact file name", file: "Makefile", want: "Makefile"},
+		{name: "file name before extension", file: "src/CMakeLists.txt", want: "Text"},
+		{name: "glob file name", file: "Dockerfile.prod", want: "Dockerfile"},
+		{name: "glob file name with a prefix", file: "build/app.dockerfile", want: "Dockerfile"},
+		{name: "extension", file: "rules.mk", want: "Makefile"},
+		{name: "shebang", file: "bin/run", content: "#!/bin/bash\necho\n", want: "Shell"},
+		{name: "shebang through env", file: "tool", content: "#!/usr/bin/env -S python3.11 -u\nprint(1)\n", want: "Python"},
+		{name: "CRLF shebang", file: "tool", content: "#!/bin/sh\r\necho\r\n", want: "Shell"},
+		{name: "unknown interpreter", file: "tool", content: "#!/usr/bin/env ruby\nputs 1\n", want: ""},
+		{name: "shebang ignored with an extension", file: "notes.md", content: "#!/bin/sh\n", want: ""},
+		{name: "no shebang", file: "LICENSE", content: "MIT\n", want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, err := NewAnalyzer("repo", nil, nil, nil, detectLanguages, ContentFilter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			a.Source = &filesystem.Source{Root: "repo", FS: fstest.MapFS{test.file: {Data: []byte(test.content)}}}
+
+			got, err := a.detectLanguage(path.Join("repo", test.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSetLanguagesClaims(t *testing.T) {
+	tests := []struct {
+		name      string
+		languages language.Languages
+		want      string
+	}{
+		{
+			name: "file name",
+			languages: language.Languages{
+				"Make":    {Filenames: []string{"Makefile"}},
+				"BSDMake": {Filenames: []string{"Makefile"}},
+			},
+			want: "file name Makefile is claimed by both BSDMake and Make",
+		},
+		{
+			name: "interpreter",
+			languages: language.Languages{
+				"Python":  {Shebangs: []string{"python"}},
+				"Jython":  {Shebangs: []string{"python"}},
+				"Python3": {Shebangs: []string{"python3"}},
+			},
+			want: "interpreter python is claimed by both Jython and Python",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The error is the same whatever the order of the map.
+			for i := 0; i < 10; i++ {
+				_, err := NewAnalyzer("repo", nil, nil, nil, test.languages, ContentFilter{})
+				if err == nil || !strings.Contains(err.Error(), test.want) {
+					t.Fatalf("got %v, want %q", err, test.want)
+				}
+			}
+		})
+	}
+}
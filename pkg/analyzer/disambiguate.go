@@ -0,0 +1,109 @@
+package analyzer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+type candidateScore struct {
+	language string
+	score    int
+	hasRules bool
+	evidence []string
+}
+
+// disambiguate chooses a language among the ones claiming the extension
+// of the file. Each matching pattern or sibling extension of a language
+// heuristic counts for one point. On a tie, a language without heuristic
+// for this extension is the default one, then names are compared so the
+// choice is always the same.
+func (a *Analyzer) disambiguate(path, extension string, candidates []string) (string, error) {
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+
+	sample, err := readSample(a.source(), path)
+	if err != nil {
+		return "", err
+	}
+
+	var best *candidateScore
+	for _, candidate := range candidates {
+		current := a.scoreCandidate(candidate, path, extension, sample)
+		if best == nil || current.better(best) {
+			best = current
+		}
+	}
+
+	if a.Logger != nil {
+		reason := "default language for " + extension
+		if len(best.evidence) > 0 {
+			reason = strings.Join(best.evidence, ", ")
+		}
+		a.Logger.Debugf("🔎 %s: %s chosen among %s (%s)", path, best.language, strings.Join(candidates, ", "), reason)
+	}
+
+	return best.language, nil
+}
+
+func (a *Analyzer) scoreCandidate(candidate, path, extension string, sample []byte) *candidateScore {
+	result := &candidateScore{language: candidate}
+
+	for _, heuristic := range a.heuristics[candidate] {
+		if len(heuristic.extensions) > 0 && !heuristic.extensions[extension] {
+			continue
+		}
+		result.hasRules = true
+
+		for _, pattern := range heuristic.patterns {
+			if pattern.re.Match(sample) {
+				result.score++
+				result.evidence = append(result.evidence, fmt.Sprintf("pattern %q", pattern.source))
+			}
+		}
+
+		siblings := a.siblingExtensions(filepath.Dir(path))
+		for _, sibling := range heuristic.siblings {
+			if siblings[sibling] {
+				result.score++
+				result.evidence = append(result.evidence, "sibling *"+sibling+" files")
+			}
+		}
+	}
+
+	return result
+}
+
+func (c *candidateScore) better(other *candidateScore) bool {
+	if c.score != other.score {
+		return c.score > other.score
+	}
+	if c.hasRules != other.hasRules {
+		return !c.hasRules
+	}
+	return c.language < other.language
+}
+
+// siblingExtensions lists the extensions found in a directory. Results are
+// cached for the duration of a walk.
+func (a *Analyzer) siblingExtensions(dir string) map[string]bool {
+	if extensions, ok := a.siblings[dir]; ok {
+		return extensions
+	}
+
+	extensions := map[string]bool{}
+	entries, _ := a.source().ReadDir(dir)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			extensions[filepath.Ext(entry.Name())] = true
+		}
+	}
+
+	if a.siblings == nil {
+		a.siblings = map[string]map[string]bool{}
+	}
+	a.siblings[dir] = extensions
+
+	return extensions
+}
@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	"bufio"
+	"path/filepath"
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/filesystem"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+const gitDir = ".git"
+
+// gitIgnore applies the .git/info/exclude file and the .gitignore files
+// found while walking the repository. The patterns of a directory are the
+// ones of its parent followed by its own, so deeper files take precedence.
+// A directory without a .gitignore file shares the rules of its parent.
+type gitIgnore struct {
+	source *filesystem.Source
+	root   string
+	dirs   map[string]*ignoreRules
+}
+
+// ignoreRules are the patterns applying to the entries of a directory,
+// and their matcher.
+type ignoreRules struct {
+	patterns []gitignore.Pattern
+	matcher  gitignore.Matcher
+}
+
+func newGitIgnore(source *filesystem.Source) *gitIgnore {
+	return &gitIgnore{
+		source: source,
+		root:   source.Root,
+		dirs:   map[string]*ignoreRules{},
+	}
+}
+
+// enterDir loads the ignore files of a directory that is not ignored.
+func (g *gitIgnore) enterDir(dir string) {
+	var patterns []gitignore.Pattern
+	domain := g.components(dir)
+	own := readIgnoreFile(g.source, filepath.Join(dir, ".gitignore"), domain)
+
+	if dir == g.root {
+		patterns = readIgnoreFile(g.source, filepath.Join(dir, gitDir, "info", "exclude"), domain)
+	} else if parent := g.dirs[filepath.Dir(dir)]; parent != nil {
+		if len(own) == 0 {
+			g.dirs[dir] = parent
+			return
+		}
+		patterns = parent.patterns[:len(parent.patterns):len(parent.patterns)]
+	}
+	patterns = append(patterns, own...)
+
+	if len(patterns) == 0 {
+		g.dirs[dir] = nil
+		return
+	}
+	g.dirs[dir] = &ignoreRules{patterns: patterns, matcher: gitignore.NewMatcher(patterns)}
+}
+
+func (g *gitIgnore) ignored(path string, isDir bool) bool {
+	if path == g.root {
+		return false
+	}
+
+	rules := g.dirs[filepath.Dir(path)]
+	if rules == nil {
+		return false
+	}
+
+	return rules.matcher.Match(g.components(path), isDir)
+}
+
+func (g *gitIgnore) components(path string) []string {
+	rel, err := filepath.Rel(g.root, path)
+	if err != nil || rel == "." {
+		return []string{}
+	}
+
+	return strings.Split(filepath.ToSlash(rel), "/")
+}
+
+func readIgnoreFile(source *filesystem.Source, path string, domain []string) []gitignore.Pattern {
+	f, err := source.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var patterns []gitignore.Pattern
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, domain))
+	}
+
+	return patterns
+}
@@ -0,0 +1,101 @@
+package analyzer
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/colussim/GoLC/pkg/filesystem"
+	"github.com/colussim/GoLC/pkg/goloc/language"
+)
+
+func TestMatchingFilesGitIgnore(t *testing.T) {
+	tree := fstest.MapFS{
+		".git/info/exclude":         {Data: []byte("# local\nscratch.go\n")},
+		".git/config.go":            {Data: []byte("package git\n")},
+		".gitignore":                {Data: []byte("*.gen.go\nbuild/\n/root_only.go\n")},
+		"main.go":                   {Data: []byte("package main\n")},
+		"scratch.go":                {Data: []byte("package main\n")},
+		"root_only.go":              {Data: []byte("package main\n")},
+		"api.gen.go":                {Data: []byte("package main\n")},
+		"build/out.go":              {Data: []byte("package build\n")},
+		"lib/root_only.go":          {Data: []byte("package lib\n")},
+		"lib/lib.gen.go":            {Data: []byte("package lib\n")},
+		"lib/deep/a.go":             {Data: []byte("package deep\n")},
+		"keep/.gitignore":           {Data: []byte("!*.gen.go\nlocal/\n")},
+		"keep/kept.gen.go":          {Data: []byte("package keep\n")},
+		"keep/local/dropped.go":     {Data: []byte("package local\n")},
+		"keep/inner/also.gen.go":    {Data: []byte("package inner\n")},
+		"keep/inner/.gitignore":     {Data: []byte("also.gen.go\r\n")},
+		"keep/inner/other.gen.go":   {Data: []byte("package inner\n")},
+		"sibling/kept_by_parent.go": {Data: []byte("package sibling\n")},
+		"sibling/dropped_by.gen.go": {Data: []byte("package sibling\n")},
+		"nested/build/out.go":       {Data: []byte("package build\n")},
+	}
+
+	a, err := NewAnalyzer("repo", nil, nil, nil, language.Languages{"Golang": {Extensions: []string{".go"}}}, ContentFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Source = &filesystem.Source{Root: "repo", FS: tree}
+	a.GitIgnore = true
+
+	files, err := a.MatchingFiles(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, file := range files {
+		got = append(got, strings.TrimPrefix(file.FilePath, "repo/"))
+	}
+	sort.Strings(got)
+
+	want := []string{
+		"keep/inner/other.gen.go",
+		"keep/kept.gen.go",
+		"lib/deep/a.go",
+		"lib/root_only.go",
+		"main.go",
+		"sibling/kept_by_parent.go",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("got %v\nwant %v", got, want)
+	}
+}
+
+func TestGitIgnoreSharesRules(t *testing.T) {
+	tree := fstest.MapFS{
+		".gitignore":      {Data: []byte("*.log\n")},
+		"a/b/c/file.go":   {Data: []byte("package c\n")},
+		"a/b/.gitignore":  {Data: []byte("!keep.log\n")},
+		"a/b/c/keep.log":  {Data: []byte("\n")},
+		"a/other.log":     {Data: []byte("\n")},
+		"a/b/c/other.log": {Data: []byte("\n")},
+	}
+	g := newGitIgnore(&filesystem.Source{Root: "repo", FS: tree})
+	for _, dir := range []string{"repo", "repo/a", "repo/a/b", "repo/a/b/c"} {
+		g.enterDir(dir)
+	}
+
+	if g.dirs["repo/a"] != g.dirs["repo"] || g.dirs["repo/a/b/c"] != g.dirs["repo/a/b"] {
+		t.Error("directories without a .gitignore file do not share the rules of their parent")
+	}
+	if len(g.dirs["repo"].patterns) != 1 {
+		t.Errorf("the patterns of the parent were changed: %d patterns", len(g.dirs["repo"].patterns))
+	}
+
+	for file, want := range map[string]bool{
+		"a/other.log":     true,
+		"a/b/c/other.log": true,
+		"a/b/c/keep.log":  false,
+		"a/b/c/file.go":   false,
+	} {
+		if got := g.ignored(path.Join("repo", file), false); got != want {
+			t.Errorf("%s: got ignored=%v, want %v", file, got, want)
+		}
+	}
+}
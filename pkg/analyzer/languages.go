@@ -0,0 +1,101 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/filesystem"
+	"github.com/colussim/GoLC/pkg/goloc/language"
+)
+
+type compiledHeuristic struct {
+	extensions map[string]bool
+	patterns   []compiledPattern
+	siblings   []string
+}
+
+type compiledPattern struct {
+	source string
+	re     *regexp.Regexp
+}
+
+// SetLanguages indexes the supported languages by extension, file name
+// and interpreter, and compiles their disambiguation heuristics. A file
+// name or an interpreter claimed by two languages is an error. The test
+// conventions of each language are compiled on first use.
+func (a *Analyzer) SetLanguages(languages language.Languages) error {
+	if err := languages.CheckClaims(); err != nil {
+		return err
+	}
+
+	extensions := map[string][]string{}
+	filenames := map[string]string{}
+	shebangs := map[string]string{}
+	heuristics := map[string][]compiledHeuristic{}
+	notebooks := map[string]bool{}
+	tests := map[string][]string{}
+
+	for name, languageInfo := range languages {
+		if languageInfo.Notebook {
+			notebooks[name] = true
+		}
+		for _, extension := range languageInfo.Extensions {
+			extensions[extension] = append(extensions[extension], name)
+		}
+		if len(languageInfo.Tests) > 0 {
+			tests[name] = languageInfo.Tests
+		}
+		for _, filename := range languageInfo.Filenames {
+			filenames[filename] = name
+		}
+		for _, shebang := range languageInfo.Shebangs {
+			shebangs[shebang] = name
+		}
+
+		for _, heuristic := range languageInfo.Heuristics {
+			compiled := compiledHeuristic{
+				extensions: map[string]bool{},
+				siblings:   heuristic.Siblings,
+			}
+			for _, extension := range heuristic.Extensions {
+				compiled.extensions[extension] = true
+			}
+			for _, pattern := range heuristic.Patterns {
+				re, err := regexp.Compile("(?m)" + pattern)
+				if err != nil {
+					return fmt.Errorf("invalid heuristic pattern %q for language %s: %v", pattern, name, err)
+				}
+				compiled.patterns = append(compiled.patterns, compiledPattern{
+					source: pattern,
+					re:     re,
+				})
+			}
+			heuristics[name] = append(heuristics[name], compiled)
+		}
+	}
+
+	for extension := range extensions {
+		sort.Strings(extensions[extension])
+	}
+
+	var filenamePatterns []string
+	for pattern := range filenames {
+		if strings.ContainsAny(pattern, "*?[") {
+			filenamePatterns = append(filenamePatterns, pattern)
+		}
+	}
+	sort.Strings(filenamePatterns)
+
+	a.SupportedExtensions = extensions
+	a.SupportedFilenames = filenames
+	a.filenamePatterns = filenamePatterns
+	a.SupportedShebangs = shebangs
+	a.heuristics = heuristics
+	a.notebooks = notebooks
+	a.tests = tests
+	a.testMatchers = map[string]*filesystem.Matcher{}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package analyzer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/colussim/GoLC/pkg/filesystem"
+)
+
+// Profile is a named set of exclusion patterns, such as the vendored
+// dependencies, whose removed files and lines are reported.
+type Profile struct {
+	Name    string
+	Matcher *filesystem.Matcher
+}
+
+// ProfileExclusion counts the files removed by a profile and their
+// physical lines, the line breaks of the files, which are not split into
+// code, comments and blank lines.
+type ProfileExclusion struct {
+	Profile       string
+	Files         int
+	PhysicalLines int
+}
+
+// pruneByProfile tells whether a directory is removed as a whole by one of
+// the profiles, and counts its files for the first profile removing it.
+// A profile with negations cannot remove a directory as a whole, its
+// files are checked one by one by excludeByProfile.
+func (a *Analyzer) pruneByProfile(ctx context.Context, dir string) (bool, error) {
+	for i, profile := range a.Profiles {
+		if !profile.Matcher.SkipDir(dir) {
+			continue
+		}
+
+		if a.Logger != nil {
+			a.Logger.Debugf("🙈 %s excluded by the %s profile", dir, profile.Name)
+		}
+		// Unreadable directories are left out of the counts.
+		err := a.source().WalkDirFrom(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err != nil {
+				return nil
+			}
+			if entry.IsDir() {
+				if entry.Name() == gitDir {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			a.countExcluded(i, path)
+			return nil
+		})
+
+		return true, err
+	}
+
+	return false, nil
+}
+
+// excludeByProfile tells whether a file is removed by one of the profiles,
+// and counts it for the first profile matching it.
+func (a *Analyzer) excludeByProfile(path string) bool {
+	for i, profile := range a.Profiles {
+		if !profile.Matcher.Match(path, false) {
+			continue
+		}
+
+		a.countExcluded(i, path)
+		if a.Logger != nil {
+			a.Logger.Debugf("🙈 %s excluded by the %s profile", path, profile.Name)
+		}
+		return true
+	}
+
+	return false
+}
+
+// countExcluded counts a file removed by the profile i. Only the files
+// whose language is known by their name or extension are counted, so that
+// the files removed are not read to detect it.
+func (a *Analyzer) countExcluded(i int, path string) {
+	extension := a.getFileExtension(path)
+	if !a.canAdd(path, extension) {
+		return
+	}
+	if _, ok := a.SupportedExtensions[extension]; !ok && a.matchFilename(filepath.Base(path)) == "" {
+		return
+	}
+
+	a.Excluded[i].Files++
+	// The lines are only reported, a file that cannot be read is still
+	// removed.
+	if lines, err := countLines(a.source(), path); err == nil {
+		a.Excluded[i].PhysicalLines += lines
+	}
+}
+
+func countLines(source *filesystem.Source, path string) (int, error) {
+	f, err := source.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	lines := 0
+	last := byte('\n')
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			lines += bytes.Count(buf[:n], []byte{'\n'})
+			last = buf[n-1]
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if last != '\n' {
+		lines++
+	}
+
+	return lines, nil
+}
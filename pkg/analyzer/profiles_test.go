@@ -0,0 +1,96 @@
+package analyzer
+
+import (
+	"context"
+	"io/fs"
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/colussim/GoLC/pkg/filesystem"
+	"github.com/colussim/GoLC/pkg/goloc/language"
+)
+
+// openFS records the files opened through it.
+type openFS struct {
+	fstest.MapFS
+	opened map[string]bool
+}
+
+func (o openFS) Open(name string) (fs.File, error) {
+	o.opened[name] = true
+	return o.MapFS.Open(name)
+}
+
+func TestMatchingFilesProfiles(t *testing.T) {
+	tree := openFS{
+		MapFS: fstest.MapFS{
+			"main.go":              {Data: []byte("package main\n\nfunc main() {}\n")},
+			"vendor/lib/lib.go":    {Data: []byte("package lib\n// one\n\n")},
+			"vendor/lib/util.go":   {Data: []byte("package lib")},
+			"vendor/lib/run":       {Data: []byte("#!/usr/bin/env python\nprint(1)\n")},
+			"vendor/lib/README.md": {Data: []byte("# lib\n")},
+			"src/vendor/inner.go":  {Data: []byte("package inner\n")},
+			"project.iml":          {Data: []byte("<module/>\n")},
+			"keep/lib/kept.go":     {Data: []byte("package kept\n")},
+			"keep/lib/dropped.go":  {Data: []byte("package dropped\n")},
+		},
+		opened: map[string]bool{},
+	}
+	languages := language.Languages{
+		"Golang": {Extensions: []string{".go"}},
+		"Python": {Extensions: []string{".py"}, Shebangs: []string{"python"}},
+		"XML":    {Extensions: []string{".iml"}},
+	}
+
+	a, err := NewAnalyzer("repo", nil, nil, nil, languages, ContentFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Source = &filesystem.Source{Root: "repo", FS: tree}
+	for _, profile := range []struct {
+		name     string
+		patterns []string
+	}{
+		{name: "vendored", patterns: []string{"vendor/"}},
+		{name: "ide", patterns: []string{"*.iml"}},
+		{name: "negated", patterns: []string{"keep/**", "!kept.go"}},
+	} {
+		matcher, err := filesystem.NewMatcher("repo", profile.patterns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a.Profiles = append(a.Profiles, Profile{Name: profile.name, Matcher: matcher})
+	}
+
+	files, err := a.MatchingFiles(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, file := range files {
+		names = append(names, file.FilePath)
+	}
+	want := []string{path.Join("repo", "keep/lib/kept.go"), path.Join("repo", "main.go")}
+	if len(names) != len(want) || names[0] != want[0] || names[1] != want[1] {
+		t.Errorf("got files %v, want %v", names, want)
+	}
+
+	wantExcluded := []ProfileExclusion{
+		{Profile: "vendored", Files: 3, PhysicalLines: 5},
+		{Profile: "ide", Files: 1, PhysicalLines: 1},
+		{Profile: "negated", Files: 1, PhysicalLines: 1},
+	}
+	for i, excluded := range a.Excluded {
+		if excluded != wantExcluded[i] {
+			t.Errorf("got %+v, want %+v", excluded, wantExcluded[i])
+		}
+	}
+
+	// The files of a pruned directory are not read to detect their
+	// language.
+	if tree.opened["vendor/lib/run"] {
+		t.Error("the files of a removed directory were read")
+	}
+}
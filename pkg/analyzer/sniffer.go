@@ -0,0 +1,225 @@
+package analyzer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"unicode/utf16"
+
+	"github.com/colussim/GoLC/pkg/filesystem"
+)
+
+const sniffLength = 8 * 1024
+
+const (
+	SkipReasonBinary    = "binary"
+	SkipReasonMinified  = "minified"
+	SkipReasonGenerated = "generated"
+)
+
+const DefaultMinifiedLineLength = 300
+
+var DefaultGeneratedMarkers = []string{
+	"DO NOT EDIT",
+	"@generated",
+	"<auto-generated",
+}
+
+// The header of a file is made of the lines starting with one of
+// headerComments, or inside one of headerBlocks, and of blank lines.
+var (
+	headerComments = []string{"//", "#", "--", ";", "%", "'", "!", "*", "<?xml"}
+	headerBlocks   = [][2]string{
+		{"/*", "*/"},
+		{"<!--", "-->"},
+		{`"""`, `"""`},
+		{"'''", "'''"},
+		{"--[[", "]]"},
+		{"{-", "-}"},
+		{"(*", "*)"},
+		{"<#", "#>"},
+		{"=begin", "=end"},
+		{"=pod", "=cut"},
+	}
+)
+
+// ContentFilter sniffs the beginning of each matching file to leave out
+// binaries, minified bundles and generated code. Each category has its
+// own toggle. Generated code is recognized by one of GeneratedMarkers in
+// the leading comments of the file, where code generators write it.
+type ContentFilter struct {
+	SkipBinary         bool
+	SkipMinified       bool
+	SkipGenerated      bool
+	MinifiedLineLength int
+	GeneratedMarkers   []string
+}
+
+type SkippedFile struct {
+	FilePath string
+	Reason   string
+}
+
+func (cf ContentFilter) enabled() bool {
+	return cf.SkipBinary || cf.SkipMinified || cf.SkipGenerated
+}
+
+// sniff returns the reason why the file must be skipped, or an empty
+// string if it has to be counted.
+func (cf ContentFilter) sniff(source *filesystem.Source, path string) (string, error) {
+	sample, err := readSample(source, path)
+	if err != nil {
+		return "", err
+	}
+
+	return cf.sniffSample(sample), nil
+}
+
+// readSample returns the first bytes of a file, enough to recognize its
+// content. UTF-16 content is decoded so that it is not taken for binary.
+func readSample(source *filesystem.Source, path string) ([]byte, error) {
+	f, err := source.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sample := make([]byte, sniffLength)
+	n, err := io.ReadFull(f, sample)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+
+	return decodeSample(sample[:n]), nil
+}
+
+func decodeSample(sample []byte) []byte {
+	var order binary.ByteOrder
+	switch {
+	case bytes.HasPrefix(sample, []byte{0xFF, 0xFE}):
+		order = binary.LittleEndian
+	case bytes.HasPrefix(sample, []byte{0xFE, 0xFF}):
+		order = binary.BigEndian
+	default:
+		return sample
+	}
+
+	units := make([]uint16, 0, len(sample)/2)
+	for i := 2; i+1 < len(sample); i += 2 {
+		units = append(units, order.Uint16(sample[i:]))
+	}
+
+	return []byte(string(utf16.Decode(units)))
+}
+
+func (cf ContentFilter) sniffSample(sample []byte) string {
+	if len(sample) == 0 {
+		return ""
+	}
+
+	if cf.SkipBinary && bytes.IndexByte(sample, 0) >= 0 {
+		return SkipReasonBinary
+	}
+
+	if cf.SkipGenerated {
+		markers := cf.GeneratedMarkers
+		if len(markers) == 0 {
+			markers = DefaultGeneratedMarkers
+		}
+		header := fileHeader(sample)
+		for _, marker := range markers {
+			if marker != "" && bytes.Contains(header, []byte(marker)) {
+				return SkipReasonGenerated
+			}
+		}
+	}
+
+	if cf.SkipMinified {
+		maxLength := cf.MinifiedLineLength
+		if maxLength <= 0 {
+			maxLength = DefaultMinifiedLineLength
+		}
+		if len(sample)/sampleLines(sample) > maxLength {
+			return SkipReasonMinified
+		}
+	}
+
+	return ""
+}
+
+// fileHeader returns the lines of the sample before the first one that is
+// neither blank nor a comment.
+func fileHeader(sample []byte) []byte {
+	rest := sample
+	closing := ""
+
+	for len(rest) > 0 {
+		line, next := nextLine(rest)
+		trimmed := bytes.TrimSpace(line)
+
+		if closing != "" {
+			if bytes.Contains(trimmed, []byte(closing)) {
+				closing = ""
+			}
+		} else if open, close := headerBlock(trimmed); open != "" {
+			if !bytes.Contains(trimmed[len(open):], []byte(close)) {
+				closing = close
+			}
+		} else if len(trimmed) > 0 && !hasAnyPrefix(trimmed, headerComments) {
+			break
+		}
+
+		rest = next
+	}
+
+	return sample[:len(sample)-len(rest)]
+}
+
+func headerBlock(line []byte) (string, string) {
+	for _, block := range headerBlocks {
+		if bytes.HasPrefix(line, []byte(block[0])) {
+			return block[0], block[1]
+		}
+	}
+
+	return "", ""
+}
+
+func hasAnyPrefix(line []byte, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if bytes.HasPrefix(line, []byte(prefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nextLine splits the sample after its first line, ended by LF, CRLF or
+// a lone CR.
+func nextLine(sample []byte) ([]byte, []byte) {
+	end := bytes.IndexAny(sample, "\r\n")
+	if end < 0 {
+		return sample, nil
+	}
+
+	next := end + 1
+	if sample[end] == '\r' && next < len(sample) && sample[next] == '\n' {
+		next++
+	}
+
+	return sample[:end], sample[next:]
+}
+
+// sampleLines counts the lines of the sample, ended by LF, CRLF or a lone
+// CR.
+func sampleLines(sample []byte) int {
+	lines := 0
+	for rest := sample; ; {
+		_, rest = nextLine(rest)
+		lines++
+		if len(rest) == 0 {
+			return lines
+		}
+	}
+}
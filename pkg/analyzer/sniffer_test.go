@@ -0,0 +1,91 @@
+package analyzer
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSniffSample(t *testing.T) {
+	filter := ContentFilter{SkipBinary: true, SkipMinified: true, SkipGenerated: true}
+	longLine := strings.Repeat("x", 400)
+
+	tests := []struct {
+		name   string
+		sample string
+		want   string
+	}{
+		{
+			name:   "go generated header",
+			sample: "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage sample\n",
+			want:   SkipReasonGenerated,
+		},
+		{
+			name:   "marker after a license block",
+			sample: "/*\n * Copyright 2024\n *\n * @generated\n */\n\nclass Sample {}\n",
+			want:   SkipReasonGenerated,
+		},
+		{
+			name:   "marker in a python docstring",
+			sample: "#!/usr/bin/env python\n\"\"\"\nGenerated file, DO NOT EDIT.\n\"\"\"\nimport os\n",
+			want:   SkipReasonGenerated,
+		},
+		{
+			name:   "marker after an xml declaration",
+			sample: "<?xml version=\"1.0\"?>\n<!-- <auto-generated /> -->\n<root/>\n",
+			want:   SkipReasonGenerated,
+		},
+		{
+			name:   "marker in the code",
+			sample: "package sample\n\nvar markers = []string{\"DO NOT EDIT\", \"@generated\"}\n",
+			want:   "",
+		},
+		{
+			name:   "marker in a comment after the code",
+			sample: "package sample\n\n// DO NOT EDIT the list below.\nvar x = 1\n",
+			want:   "",
+		},
+		{
+			name:   "binary",
+			sample: "\x7fELF\x00\x01",
+			want:   SkipReasonBinary,
+		},
+		{
+			name:   "minified",
+			sample: longLine + "\n" + longLine + "\n",
+			want:   SkipReasonMinified,
+		},
+		{
+			name:   "short lines ended by CR",
+			sample: strings.Repeat("var x = 1;\r", 200),
+			want:   "",
+		},
+		{
+			name:   "short lines ended by CRLF",
+			sample: strings.Repeat("var x = 1;\r\n", 200),
+			want:   "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := filter.sniffSample([]byte(test.sample)); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// The sniffer names the default markers in its own code, which must not
+// make it look generated.
+func TestSniffSampleOwnSource(t *testing.T) {
+	sample, err := os.ReadFile("sniffer.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter := ContentFilter{SkipGenerated: true}
+	if got := filter.sniffSample(sample); got != "" {
+		t.Errorf("sniffer.go is skipped as %s", got)
+	}
+}
@@ -0,0 +1,26 @@
+package analyzer
+
+import (
+	"github.com/colussim/GoLC/pkg/filesystem"
+)
+
+// isTest tells whether a file is a test, by the conventions of its
+// language unless one of the TestPatterns matches it, so that a ! pattern
+// can override them.
+func (a *Analyzer) isTest(path string, language string) (bool, error) {
+	if isTest, matched := a.testPatterns.Lookup(path, false); matched {
+		return isTest, nil
+	}
+
+	matcher, ok := a.testMatchers[language]
+	if !ok {
+		var err error
+		matcher, err = filesystem.NewMatcher(a.path, a.tests[language])
+		if err != nil {
+			return false, err
+		}
+		a.testMatchers[language] = matcher
+	}
+
+	return matcher.Match(path, false), nil
+}
@@ -0,0 +1,87 @@
+package analyzer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/colussim/GoLC/pkg/goloc/language"
+)
+
+// testTree writes empty files to a temporary directory and returns it.
+func testTree(t *testing.T, files ...string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	for _, file := range files {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("package a\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func TestMatchingFilesTests(t *testing.T) {
+	root := testTree(t, "a.go", "a_test.go", "integration/b.go", "fixtures/c_test.go")
+	languages := language.Languages{
+		"Golang": {Extensions: []string{".go"}, Tests: []string{"*_test.go"}},
+	}
+
+	a, err := NewAnalyzer(root, nil, nil, nil, languages, ContentFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.TestPatterns = []string{"integration/", "!fixtures/"}
+
+	files, err := a.MatchingFiles(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]bool{
+		"a.go":               false,
+		"a_test.go":          true,
+		"integration/b.go":   true,
+		"fixtures/c_test.go": false,
+	}
+	if len(files) != len(want) {
+		t.Fatalf("got %d files, want %d", len(files), len(want))
+	}
+	for _, file := range files {
+		rel, _ := filepath.Rel(root, file.FilePath)
+		if isTest := want[filepath.ToSlash(rel)]; file.IsTest != isTest {
+			t.Errorf("%s: got IsTest=%v, want %v", rel, file.IsTest, isTest)
+		}
+	}
+}
+
+func TestMatchingFilesTestsError(t *testing.T) {
+	root := testTree(t, "a.go")
+	languages := language.Languages{
+		"Golang": {Extensions: []string{".go"}, Tests: []string{"["}},
+	}
+
+	a, err := NewAnalyzer(root, nil, nil, nil, languages, ContentFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := a.MatchingFiles(context.Background())
+	if err != nil {
+		t.Fatalf("the walk failed: %v", err)
+	}
+	if len(files) != 0 || len(a.Errors) != 1 {
+		t.Errorf("got %d files and %d errors, want the file recorded as an error", len(files), len(a.Errors))
+	}
+
+	a.Strict = true
+	if _, err := a.MatchingFiles(context.Background()); err == nil {
+		t.Error("a strict walk did not fail")
+	}
+}
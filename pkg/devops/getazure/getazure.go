@@ -9,7 +9,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/briandowns/spinner"
+	"github.com/colussim/GoLC/pkg/events"
 	"github.com/colussim/GoLC/pkg/utils"
 	"github.com/microsoft/azure-devops-go-api/azuredevops"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
@@ -54,7 +54,6 @@ type AnalyzeProject struct {
 	AzureClient   core.Client
 	Context       context.Context
 	ExclusionList *ExclusionList
-	Spin1         *spinner.Spinner
 	Org           string
 }
 
@@ -68,7 +67,6 @@ type ParamsProjectAzure struct {
 	Organization   string
 	Exclusionlist  *utils.ExclusionList
 	Excludeproject int
-	Spin           *spinner.Spinner
 	Period         int
 	Stats          bool
 	DefaultB       bool
@@ -205,15 +203,12 @@ func GetRepoAzureList(platformConfig map[string]interface{}, exclusionFile strin
 
 	loggers.Infof("🔎 Analysis of devops platform objects ...\n")
 
-	spin := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin.Prefix = PrefixMsg
-	spin.Color("green", "bold")
-	spin.Start()
+	events.Start(PrefixMsg)
 
 	exclusionList, err = loadExclusionFileOrCreateNew(exclusionFile)
 	if err != nil {
 		loggers.Errorf("\n❌ Error Read Exclusion File <%s>: %v", exclusionFile, err)
-		spin.Stop()
+		events.Finish("")
 		return nil, err
 	}
 
@@ -244,43 +239,39 @@ func GetRepoAzureList(platformConfig map[string]interface{}, exclusionFile strin
 		projects, exludedprojects, err := getAllProjects(ctx, coreClient, exclusionList)
 
 		if err != nil {
-			spin.Stop()
+			events.Finish("")
 			loggers.Fatalf(MessageErro1, platformConfig["Organization"].(string), err)
 		}
-		spin.Stop()
-		spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-		spin1.Color("green", "bold")
+		events.Finish("")
 
 		loggers.Infof(Message1, Message4, len(projects)+exludedprojects)
 
 		// Set Parmams
-		params := getCommonParams(azureConnect, platformConfig, projects, exclusionList, exludedprojects, spin, ApiURL)
+		params := getCommonParams(azureConnect, platformConfig, projects, exclusionList, exludedprojects, ApiURL)
 		// Analyse Get important Branch
 		importantBranches, emptyRepo, nbRepos, TotalBranches, totalExclude, totalArchiv, err = getRepoAnalyse(params, gitClient)
 		if err != nil {
-			spin.Stop()
+			events.Finish("")
 			return nil, err
 		}
 
 	} else {
 		projects, exludedprojects, err := getProjectByName(ctx, coreClient, platformConfig["Project"].(string), exclusionList)
 		if err != nil {
-			spin.Stop()
+			events.Finish("")
 			log.Fatalf(MessageErro2, platformConfig["Organization"].(string), err)
 		}
 
-		spin.Stop()
-		spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-		spin1.Color("green", "bold")
+		events.Finish("")
 
 		loggers.Infof(Message1, Message4, 1+exludedprojects)
 
 		// Set Parmams
-		params := getCommonParams(azureConnect, platformConfig, projects, exclusionList, exludedprojects, spin, ApiURL)
+		params := getCommonParams(azureConnect, platformConfig, projects, exclusionList, exludedprojects, ApiURL)
 		// Analyse Get important Branch
 		importantBranches, emptyRepo, nbRepos, TotalBranches, totalExclude, totalArchiv, err = getRepoAnalyse(params, gitClient)
 		if err != nil {
-			spin.Stop()
+			events.Finish("")
 			return nil, err
 		}
 	}
@@ -317,11 +308,19 @@ func GetRepoAzureList(platformConfig map[string]interface{}, exclusionFile strin
 	}
 
 	printSummary(platformConfig["Organization"].(string), stats)
+	emitDiscovered(importantBranches)
 
 	return importantBranches, nil
 }
 
-func getCommonParams(azureConnect AzureConnect, platformConfig map[string]interface{}, project []core.TeamProjectReference, exclusionList *utils.ExclusionList, excludeproject int, spin *spinner.Spinner, apiURL string) ParamsProjectAzure {
+// Send a RepoDiscovered event for each repository kept for the analysis
+func emitDiscovered(importantBranches []ProjectBranch) {
+	for _, branch := range importantBranches {
+		events.Emit(events.Event{Type: events.RepoDiscovered, Repository: branch.RepoSlug, Branch: branch.MainBranch})
+	}
+}
+
+func getCommonParams(azureConnect AzureConnect, platformConfig map[string]interface{}, project []core.TeamProjectReference, exclusionList *utils.ExclusionList, excludeproject int, apiURL string) ParamsProjectAzure {
 	return ParamsProjectAzure{
 		Client:   azureConnect.CoreClient,
 		Context:  azureConnect.Ctx,
@@ -333,7 +332,6 @@ func getCommonParams(azureConnect AzureConnect, platformConfig map[string]interf
 		Organization:   platformConfig["Organization"].(string),
 		Exclusionlist:  exclusionList,
 		Excludeproject: excludeproject,
-		Spin:           spin,
 		Period:         int(platformConfig["Period"].(float64)),
 		Stats:          platformConfig["Stats"].(bool),
 		DefaultB:       platformConfig["DefaultBranch"].(bool),
@@ -384,18 +382,13 @@ func getRepoAnalyse(params ParamsProjectAzure, gitClient git.Client) ([]ProjectB
 
 	message4 := "Repo(s)"
 
-	spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin1.Prefix = PrefixMsg
-	spin1.Color("green", "bold")
-
-	params.Spin.Start()
+	events.Start(PrefixMsg)
 	if params.Excludeproject > 0 {
 		messageF = fmt.Sprintf("\t✅ The number of project(s) to analyze is %d - Excluded : %d\n\n", len(params.Projects), params.Excludeproject)
 	} else {
 		messageF = fmt.Sprintf("\t✅ The number of project(s) to analyze is %d\n\n", len(params.Projects))
 	}
-	params.Spin.FinalMSG = messageF
-	params.Spin.Stop()
+	events.Finish(messageF)
 
 	// Get Repository in each Project
 	for _, project := range params.Projects {
@@ -407,18 +400,18 @@ func getRepoAnalyse(params ParamsProjectAzure, gitClient git.Client) ([]ProjectB
 		if err != nil {
 			if len(params.SingleRepos) == 0 {
 				loggers.Errorf("\r❌ Get Repos for each Project:", err)
-				spin1.Stop()
+				events.Finish("")
 				continue
 			} else {
 				errmessage := fmt.Sprintf(" Get Repo %s for Project %s %v", params.SingleRepos, *project.Name, err)
-				spin1.Stop()
+				events.Finish("")
 				return importantBranches, emptyRepos, NBRrepos, TotalBranches, totalexclude, cptarchiv, fmt.Errorf(errmessage)
 			}
 		}
 
 		totalexclude = totalexclude + excludedCount
 
-		spin1.Stop()
+		events.Finish("")
 		if emptyOrArchivedCount > 0 {
 			NBRrepo = len(repos) + emptyOrArchivedCount
 			loggers.Infof("\t  ✅ The number of %s found is: %d - Find empty %d:\n", message4, NBRrepo, emptyOrArchivedCount)
@@ -429,7 +422,7 @@ func getRepoAnalyse(params ParamsProjectAzure, gitClient git.Client) ([]ProjectB
 
 		for _, repo := range repos {
 
-			largestRepoBranch, repobranches, brsize, err := analyzeRepoBranches(params, *project.Name, *repo.Name, gitClient, cpt, spin1)
+			largestRepoBranch, repobranches, brsize, err := analyzeRepoBranches(params, *project.Name, *repo.Name, gitClient, cpt)
 
 			if err != nil {
 				if params.SingleBranch != "" {
@@ -525,7 +518,7 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
-func analyzeRepoBranches(parms ParamsProjectAzure, projectKey string, repo string, gitClient git.Client, cpt int, spin1 *spinner.Spinner) (string, int, int64, error) {
+func analyzeRepoBranches(parms ParamsProjectAzure, projectKey string, repo string, gitClient git.Client, cpt int) (string, int, int64, error) {
 
 	var largestRepoBranch string
 	var nbrbranch int
@@ -535,11 +528,11 @@ func analyzeRepoBranches(parms ParamsProjectAzure, projectKey string, repo strin
 
 	largestRepoBranch, brsize, nbrbranch, err = getMostImportantBranch(parms.Context, gitClient, projectKey, repo, parms.Period, parms.DefaultB, parms.SingleBranch)
 	if err != nil {
-		spin1.Stop()
+		events.Finish("")
 		return "", 0, 1, err
 	}
 
-	spin1.Stop()
+	events.Finish("")
 
 	// Print analysis summary
 	loggers.Infof("\t\t✅ Repo %d: %s - Number of branches: %d - Largest Branch: %s\n", cpt, repo, nbrbranch, largestRepoBranch)
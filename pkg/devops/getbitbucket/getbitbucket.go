@@ -9,9 +9,8 @@ import (
 	"os"
 	"strings"
 	"sync"
-	"time"
 
-	"github.com/briandowns/spinner"
+	"github.com/colussim/GoLC/pkg/events"
 	"github.com/colussim/GoLC/pkg/utils"
 )
 
@@ -145,7 +144,6 @@ type ParamsReposProjectCloud struct {
 	Workspace        string
 	NBRepos          int
 	ExclusionList    *ExclusionList
-	Spin             *spinner.Spinner
 	Branch           string
 }
 
@@ -219,14 +217,9 @@ func GetReposProjectCloud(parms ParamsReposProjectCloud) ([]ProjectBranch, int,
 	emptyRepo := 0
 	result := AnalysisResult{}
 
-	spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin1.Prefix = PrefixMsg
-	spin1.Color("green", "bold")
-
-	parms.Spin.Start()
+	events.Start(PrefixMsg)
 	messageF := fmt.Sprintf("✅ The number of project(s) to analyze is %d\n", len(parms.Projects))
-	parms.Spin.FinalMSG = messageF
-	parms.Spin.Stop()
+	events.Finish(messageF)
 
 	for _, project := range parms.Projects {
 
@@ -241,10 +234,10 @@ func GetReposProjectCloud(parms ParamsReposProjectCloud) ([]ProjectBranch, int,
 		repos, err := CloudAllRepos(urlrepos, parms.AccessToken, parms.ExclusionList)
 		if err != nil {
 			fmt.Println("\r❌ Get Repos for each Project:", err)
-			spin1.Stop()
+			events.Finish("")
 			continue
 		}
-		parms.Spin.Stop()
+		events.Finish("")
 
 		parms.NBRepos += len(repos)
 		message4 = "Repo(s)"
@@ -260,7 +253,7 @@ func GetReposProjectCloud(parms ParamsReposProjectCloud) ([]ProjectBranch, int,
 			isEmpty, err := isRepositoryEmpty(parms.Workspace, repo.Slug, parms.AccessToken, parms.BitbucketURLBase)
 			if err != nil {
 				fmt.Printf("❌ Error when Testing if repo is empty %s: %v\n", repo.Name, err)
-				spin1.Stop()
+				events.Finish("")
 				continue
 			}
 
@@ -273,7 +266,7 @@ func GetReposProjectCloud(parms ParamsReposProjectCloud) ([]ProjectBranch, int,
 					branches, err = CloudAllBranches(urlrepos, parms.AccessToken)
 					if err != nil {
 						fmt.Printf("❌ Error when retrieving branches for repo %s: %v\n", repo.Name, err)
-						spin1.Stop()
+						events.Finish("")
 						continue
 					}
 				} else {
@@ -295,17 +288,15 @@ func GetReposProjectCloud(parms ParamsReposProjectCloud) ([]ProjectBranch, int,
 					if len(branches) > 1 {
 						for _, branch := range branches {
 							messageB := fmt.Sprintf("\t   Analysis branch <%s> size...", branch.Name)
-							spin1.Prefix = messageB
-							spin1.Start()
+							events.Start(messageB)
 
 							size, err := fetchBranchSize(parms.Workspace, repo.Slug, branch.Name, parms.AccessToken, parms.URL, parms.APIVersion)
 							messageF = ""
-							spin1.FinalMSG = messageF
 
-							spin1.Stop()
+							events.Finish(messageF)
 							if err != nil {
 								fmt.Println("❌ Error retrieving branch size:", err)
-								spin1.Stop()
+								events.Finish("")
 								os.Exit(1)
 							}
 
@@ -321,7 +312,7 @@ func GetReposProjectCloud(parms ParamsReposProjectCloud) ([]ProjectBranch, int,
 
 						if err1 != nil {
 							fmt.Println("\n❌ Error retrieving branch size:", err1)
-							spin1.Stop()
+							events.Finish("")
 							os.Exit(1)
 						}
 						largestRepoSize = size1
@@ -375,16 +366,12 @@ func GetRepos(parms ParamsReposCloud) ([]ProjectBranch, int, int) {
 	nbRepos := 1
 	result := AnalysisResult{}
 
-	spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin1.Prefix = PrefixMsg
-	spin1.Color("green", "bold")
-
 	fmt.Printf("\n🟢 Analyse Projet: %s \n", parms.Projects)
 
 	isEmpty, err := isRepositoryEmpty(parms.Workspace, parms.Repos[0].Slug, parms.AccessToken, parms.BitbucketURLBase)
 	if err != nil {
 		fmt.Printf("❌ Error when Testing if repo is empty %s: %v\n", parms.Repos[0].Name, err)
-		spin1.Stop()
+		events.Finish("")
 		os.Exit(1)
 	}
 
@@ -396,7 +383,7 @@ func GetRepos(parms ParamsReposCloud) ([]ProjectBranch, int, int) {
 			branches, err = CloudAllBranches(urlrepos, parms.AccessToken)
 			if err != nil {
 				fmt.Printf("❌ Error when retrieving branches for repo %s: %v\n", parms.Repos[0].Name, err)
-				spin1.Stop()
+				events.Finish("")
 				os.Exit(1)
 			}
 
@@ -420,17 +407,15 @@ func GetRepos(parms ParamsReposCloud) ([]ProjectBranch, int, int) {
 			if len(branches) > 1 {
 				for _, branch := range branches {
 					messageB := fmt.Sprintf("\t   Analysis branch <%s> size...", branch.Name)
-					spin1.Prefix = messageB
-					spin1.Start()
+					events.Start(messageB)
 
 					size, err := fetchBranchSize(parms.Workspace, parms.Repos[0].Slug, branch.Name, parms.AccessToken, parms.URL, parms.APIVersion)
 					messageF := ""
-					spin1.FinalMSG = messageF
 
-					spin1.Stop()
+					events.Finish(messageF)
 					if err != nil {
 						fmt.Println("❌ Error retrieving branch size:", err)
-						spin1.Stop()
+						events.Finish("")
 						continue
 					}
 
@@ -446,7 +431,7 @@ func GetRepos(parms ParamsReposCloud) ([]ProjectBranch, int, int) {
 
 				if err1 != nil {
 					fmt.Println("\n❌ Error retrieving branch size:", err1)
-					spin1.Stop()
+					events.Finish("")
 					os.Exit(1)
 				}
 				largestRepoSize = size1
@@ -509,10 +494,6 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exlusio
 
 	fmt.Print("\n🔎 Analysis of devops platform objects ...\n")
 
-	spin := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin.Prefix = PrefixMsg
-	spin.Color("green", "bold")
-
 	if exlusionfile == "0" {
 		exclusionList = &ExclusionList{
 			Projectcs: make(map[string]bool),
@@ -523,7 +504,7 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exlusio
 		exclusionList, err1 = loadExclusionList(exlusionfile)
 		if err1 != nil {
 			fmt.Printf("\n❌ Error Read Exclusion File <%s>: %v", exlusionfile, err1)
-			spin.Stop()
+			events.Finish("")
 			return nil, err1
 		}
 
@@ -534,10 +515,10 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exlusio
 		projects, err1 = CloudAllProjects(bitbucketURL, platformConfig["AccessToken"].(string), exclusionList)
 		if err1 != nil {
 			fmt.Println("\r❌ Error Get All Projects:", err1)
-			spin.Stop()
+			events.Finish("")
 			return nil, err1
 		}
-		spin.Stop()
+		events.Finish("")
 
 		parms := ParamsReposProjectCloud{
 			Projects:         projects,
@@ -549,7 +530,6 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exlusio
 			Workspace:        platformConfig["Workspace"].(string),
 			NBRepos:          nbRepos,
 			ExclusionList:    exclusionList,
-			Spin:             spin,
 			Branch:           platformConfig["Branch"].(string),
 		}
 
@@ -561,20 +541,20 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exlusio
 			fmt.Println("\n❌ Projet", platformConfig["Project"].(string), "is excluded from the analysis... edit <.cloc_bitbucket_ignore> file")
 			os.Exit(1)
 		} else {
-			spin.Start()
+			events.Start(PrefixMsg)
 			bitbucketURLProject := fmt.Sprintf("%s%s/workspaces/%s/projects/%s", platformConfig["Url"].(string), platformConfig["Apiver"].(string), platformConfig["Workspace"].(string), platformConfig["Project"].(string))
 
 			projects, err := CloudOnelProjects(bitbucketURLProject, platformConfig["AccessToken"].(string), exclusionList)
 			if err != nil {
 				fmt.Printf("\n❌ Error Get Project:%s - %v", platformConfig["Project"].(string), err)
-				spin.Stop()
+				events.Finish("")
 				return nil, err
 			}
-			spin.Stop()
+			events.Finish("")
 
 			if len(projects) == 0 {
 				fmt.Printf("\n❌ Error Project:%s not exist - %v", platformConfig["Project"].(string), err)
-				spin.Stop()
+				events.Finish("")
 				os.Exit(1)
 				//return nil, err
 			} else {
@@ -588,7 +568,6 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exlusio
 					Workspace:        platformConfig["Workspace"].(string),
 					NBRepos:          nbRepos,
 					ExclusionList:    exclusionList,
-					Spin:             spin,
 					Branch:           platformConfig["Branch"].(string),
 				}
 				importantBranches, nbRepos, emptyRepo = GetReposProjectCloud(parms)
@@ -607,7 +586,7 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exlusio
 			Repos, err := fetchOneRepos(bitbucketURLProject, platformConfig["AccessToken"].(string), exclusionList)
 			if err != nil {
 				fmt.Printf("\n❌ Error Get Repo:%s/%s - %v", platformConfig["Project"].(string), platformConfig["Repos"].(string), err)
-				spin.Stop()
+				events.Finish("")
 				return nil, err
 			}
 			parms := ParamsReposCloud{
@@ -626,7 +605,7 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exlusio
 			importantBranches, nbRepos, emptyRepo = GetRepos(parms)
 		}
 	} else {
-		spin.Stop()
+		events.Finish("")
 		fmt.Println("❌ Error Project name is empty")
 		os.Exit(1)
 	}
@@ -655,6 +634,7 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exlusio
 	result.NumProjects = 1
 	result.NumRepositories = nbRepos
 	result.ProjectBranches = importantBranches
+	emitDiscovered(importantBranches)
 
 	// Save Result of Analysis
 	file, err := os.Create("Results/config/analysis_repos_bitbucketdc.json")
@@ -674,6 +654,13 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exlusio
 	return importantBranches, nil
 }
 
+// Send a RepoDiscovered event for each repository kept for the analysis
+func emitDiscovered(importantBranches []ProjectBranch) {
+	for _, branch := range importantBranches {
+		events.Emit(events.Event{Type: events.RepoDiscovered, Repository: branch.RepoSlug, Branch: branch.MainBranch})
+	}
+}
+
 func CloudAllProjects(url string, accessToken string, exclusionList *ExclusionList) ([]Projectc, error) {
 	var allProjects []Projectc
 
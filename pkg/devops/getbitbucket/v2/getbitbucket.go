@@ -9,7 +9,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/briandowns/spinner"
+	"github.com/colussim/GoLC/pkg/events"
 	"github.com/colussim/GoLC/pkg/utils"
 	"github.com/ktrysmt/go-bitbucket"
 )
@@ -72,7 +72,6 @@ type ParamsProjectBitbucket struct {
 	Organization     string
 	Exclusionlist    *utils.ExclusionList
 	Excludeproject   int
-	Spin             *spinner.Spinner
 	Period           int
 	Stats            bool
 	DefaultB         bool
@@ -142,14 +141,10 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exclusi
 
 	loggers.Infof("🔎 Analysis of devops platform objects ...\n")
 
-	spin := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin.Prefix = "Processing"
-	spin.Color("green", "bold")
-
 	exclusionList, err = loadExclusionFileOrCreateNew(exclusionFile)
 	if err != nil {
 		loggers.Errorf("❌ Error Read Exclusion File <%s>: %v", exclusionFile, err)
-		spin.Stop()
+		events.Finish("")
 		return nil, err
 	}
 
@@ -164,23 +159,23 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exclusi
 		projects, exludedprojects, err = getAllProjects(client, platformConfig["Workspace"].(string), exclusionList)
 		if err != nil {
 			loggers.Errorf("\r❌ Error Get All Projects:%v", err)
-			spin.Stop()
+			events.Finish("")
 			return nil, err
 		}
 	} else if len(project) != 0 {
 		//else if len(project) != 0 && len(repos) == 0 {
 		projects, exludedprojects, err = getSepecificProjects(client, platformConfig["Workspace"].(string), project, exclusionList)
 		if err != nil {
-			spin.Stop()
+			events.Finish("")
 			return nil, err
 		}
 	}
-	spin.Stop()
+	events.Finish("")
 
-	params := getCommonParams(client, platformConfig, projects, exclusionList, exludedprojects, spin, bitbucketURLBase)
+	params := getCommonParams(client, platformConfig, projects, exclusionList, exludedprojects, bitbucketURLBase)
 	importantBranches, emptyRepo, nbRepos, TotalBranches, totalExclude, totalArchiv, err = getRepoAnalyse(params)
 	if err != nil {
-		spin.Stop()
+		events.Finish("")
 		return nil, err
 	}
 
@@ -206,10 +201,18 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exclusi
 	}
 
 	printSummary(params.Organization, stats)
+	emitDiscovered(importantBranches)
 
 	return importantBranches, nil
 }
 
+// Send a RepoDiscovered event for each repository kept for the analysis
+func emitDiscovered(importantBranches []ProjectBranch) {
+	for _, branch := range importantBranches {
+		events.Emit(events.Event{Type: events.RepoDiscovered, Repository: branch.RepoSlug, Branch: branch.MainBranch})
+	}
+}
+
 func findLargestRepository(importantBranches []ProjectBranch, totalSize *int) (string, string) {
 
 	var largestRepoBranch, largesRepo string
@@ -283,7 +286,7 @@ func GetSize(parms ParamsProjectBitbucket, repo *bitbucket.Repository) (int, err
 
 }
 
-func getCommonParams(client *bitbucket.Client, platformConfig map[string]interface{}, project []Projectc, exclusionList *utils.ExclusionList, excludeproject int, spin *spinner.Spinner, bitbucketURLBase string) ParamsProjectBitbucket {
+func getCommonParams(client *bitbucket.Client, platformConfig map[string]interface{}, project []Projectc, exclusionList *utils.ExclusionList, excludeproject int, bitbucketURLBase string) ParamsProjectBitbucket {
 	return ParamsProjectBitbucket{
 		Client:           client,
 		Projects:         project,
@@ -296,7 +299,6 @@ func getCommonParams(client *bitbucket.Client, platformConfig map[string]interfa
 		Organization:     platformConfig["Organization"].(string),
 		Exclusionlist:    exclusionList,
 		Excludeproject:   excludeproject,
-		Spin:             spin,
 		Period:           int(platformConfig["Period"].(float64)),
 		Stats:            platformConfig["Stats"].(bool),
 		DefaultB:         platformConfig["DefaultBranch"].(bool),
@@ -383,18 +385,13 @@ func getRepoAnalyse(params ParamsProjectBitbucket) ([]ProjectBranch, int, int, i
 
 	message4 := "Repo(s)"
 
-	spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin1.Prefix = PrefixMsg
-	spin1.Color("green", "bold")
-
-	params.Spin.Start()
+	events.Start("Processing")
 	if params.Excludeproject > 0 {
 		messageF = fmt.Sprintf("✅ The number of project(s) to analyze is %d - Excluded : %d\n\n", len(params.Projects), params.Excludeproject)
 	} else {
 		messageF = fmt.Sprintf("✅ The number of project(s) to analyze is %d\n\n", len(params.Projects))
 	}
-	params.Spin.FinalMSG = messageF
-	params.Spin.Stop()
+	events.Finish(messageF)
 
 	// Get Repository in each Project
 	for _, project := range params.Projects {
@@ -406,18 +403,18 @@ func getRepoAnalyse(params ParamsProjectBitbucket) ([]ProjectBranch, int, int, i
 		if err != nil {
 			if len(params.SingleRepos) == 0 {
 				loggers.Errorf("❌ Get Repos for each Project:%v", err)
-				spin1.Stop()
+				events.Finish("")
 				continue
 			} else {
 				errmessage := fmt.Sprintf(" Get Repo %s for Project %s %v", params.SingleRepos, project.Key, err)
-				spin1.Stop()
+				events.Finish("")
 				return importantBranches, emptyRepos, NBRrepos, TotalBranches, totalexclude, cptarchiv, fmt.Errorf(errmessage)
 			}
 		}
 		emptyRepos = emptyRepos + emptyOrArchivedCount
 		totalexclude = totalexclude + excludedCount
 
-		spin1.Stop()
+		events.Finish("")
 		if emptyOrArchivedCount > 0 {
 			NBRrepo = len(repos) + emptyOrArchivedCount
 			loggers.Infof("\t  ✅ The number of %s found is: %d - Find empty %d:", message4, NBRrepo, emptyOrArchivedCount)
@@ -428,7 +425,7 @@ func getRepoAnalyse(params ParamsProjectBitbucket) ([]ProjectBranch, int, int, i
 
 		for _, repo := range repos {
 
-			largestRepoBranch, repobranches, brsize, err := analyzeRepoBranches(params, repo, cpt, spin1)
+			largestRepoBranch, repobranches, brsize, err := analyzeRepoBranches(params, repo, cpt)
 			if err != nil {
 				largestRepoBranch = repo.Mainbranch.Name
 
@@ -607,7 +604,7 @@ func fetchFiles(url string, accessToken string) (*Response1, error) {
 	return &filesResp, nil
 }
 
-func analyzeRepoBranches(parms ParamsProjectBitbucket, repo *bitbucket.Repository, cpt int, spin1 *spinner.Spinner) (string, []*bitbucket.RepositoryBranch, int, error) {
+func analyzeRepoBranches(parms ParamsProjectBitbucket, repo *bitbucket.Repository, cpt int) (string, []*bitbucket.RepositoryBranch, int, error) {
 
 	var repoBranches []*bitbucket.RepositoryBranch
 	var largestRepoBranch string
@@ -615,8 +612,7 @@ func analyzeRepoBranches(parms ParamsProjectBitbucket, repo *bitbucket.Repositor
 	var brsize, nbrbranche int
 	loggers := utils.NewLogger()
 
-	spin1.Prefix = "\r Analyzing branches"
-	spin1.Start()
+	events.Start("\r Analyzing branches")
 
 	if parms.DefaultB || len(parms.SingleBranch) != 0 {
 		var branchName string
@@ -625,9 +621,9 @@ func analyzeRepoBranches(parms ParamsProjectBitbucket, repo *bitbucket.Repositor
 		} else if len(parms.SingleBranch) != 0 {
 			branchName = parms.SingleBranch
 		}
-		repoBranches, largestRepoBranch, brsize, err = getSingleBranches(parms, branchName, repo.Slug, spin1)
+		repoBranches, largestRepoBranch, brsize, err = getSingleBranches(parms, branchName, repo.Slug)
 		if err != nil {
-			spin1.Stop()
+			events.Finish("")
 			return "", nil, 0, err
 		}
 		nbrbranche = 1
@@ -635,21 +631,21 @@ func analyzeRepoBranches(parms ParamsProjectBitbucket, repo *bitbucket.Repositor
 	} else {
 		repoBranches, err := getAllBranches(parms.Client, parms.Workspace, repo.Slug)
 		if err != nil {
-			spin1.Stop()
+			events.Finish("")
 			return "", nil, 0, err
 		}
 
 		// Determine the largest branch based on the number of commits
 		largestRepoBranch, brsize = determineLargestBranch(parms, repo, repoBranches)
 		if err != nil {
-			spin1.Stop()
+			events.Finish("")
 			return "", nil, 1, err
 		}
 		nbrbranche = len(repoBranches)
 
 	}
 
-	spin1.Stop()
+	events.Finish("")
 
 	// Print analysis summary
 	loggers.Infof("\t\t✅ Repo %d: %s - Number of branches: %d - Largest Branch: %s", cpt, repo.Slug, nbrbranche, largestRepoBranch)
@@ -657,7 +653,7 @@ func analyzeRepoBranches(parms ParamsProjectBitbucket, repo *bitbucket.Repositor
 	return largestRepoBranch, repoBranches, brsize, nil
 }
 
-func getSingleBranches(parms ParamsProjectBitbucket, singlebranch string, repoSlug string, spin1 *spinner.Spinner) ([]*bitbucket.RepositoryBranch, string, int, error) {
+func getSingleBranches(parms ParamsProjectBitbucket, singlebranch string, repoSlug string) ([]*bitbucket.RepositoryBranch, string, int, error) {
 
 	var repoBranches1 []*bitbucket.RepositoryBranch
 
@@ -667,7 +663,7 @@ func getSingleBranches(parms ParamsProjectBitbucket, singlebranch string, repoSl
 		BranchName: singlebranch,
 	})
 	if err != nil {
-		spin1.Stop()
+		events.Finish("")
 		return repoBranches1, "", 0, err
 	}
 	for _, branch := range branchesRes1.Branches {
@@ -9,9 +9,8 @@ import (
 	"os"
 	"strings"
 	"sync"
-	"time"
 
-	"github.com/briandowns/spinner"
+	"github.com/colussim/GoLC/pkg/events"
 	"github.com/colussim/GoLC/pkg/utils"
 )
 
@@ -103,7 +102,6 @@ type ParamsReposDC struct {
 	BitbucketURLBase string
 	ExclusionList    *utils.ExclusionList
 	Branch           string
-	Spin             *spinner.Spinner
 	DefaultB         bool
 }
 
@@ -174,7 +172,6 @@ type ParamsReposProjectDC struct {
 	BitbucketURLBase string
 	NBRepos          int
 	ExclusionList    *utils.ExclusionList
-	Spin             *spinner.Spinner
 	Branch           string
 	DefaultB         bool
 }
@@ -200,13 +197,8 @@ func GetReposProject(projects []Project, parms ParamsReposProjectDC, bitbucketUR
 	result := AnalysisResult{}
 	loggers := utils.NewLogger()
 
-	spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin1.Prefix = "Get Projects... "
-	spin1.Color("green", "bold")
-
-	parms.Spin.Start()
-	parms.Spin.FinalMSG = fmt.Sprintf("\n✅ The number of project(s) to analyze is %d\n\n", len(projects))
-	parms.Spin.Stop()
+	events.Start("Get Projects... ")
+	events.Finish(fmt.Sprintf("\n✅ The number of project(s) to analyze is %d\n\n", len(projects)))
 
 	for _, project := range projects {
 		fmt.Print("\n")
@@ -223,7 +215,7 @@ func GetReposProject(projects []Project, parms ParamsReposProjectDC, bitbucketUR
 		loggers.Infof("\t  ✅ The number of Repo(s) found is: %d", len(repos))
 
 		for _, repo := range repos {
-			if err := processRepo(project.Key, repo, parms, bitbucketURLBase, spin1, &importantBranches); err != nil {
+			if err := processRepo(project.Key, repo, parms, bitbucketURLBase, &importantBranches); err != nil {
 				if err == ErrEmptyRepo {
 					emptyRepo++
 				} else {
@@ -245,7 +237,7 @@ func GetReposProject(projects []Project, parms ParamsReposProjectDC, bitbucketUR
 	return importantBranches, nbRepos, emptyRepo
 }
 
-func processRepo(projectKey string, repo Repo, parms ParamsReposProjectDC, bitbucketURLBase string, spin1 *spinner.Spinner, importantBranches *[]ProjectBranch) error {
+func processRepo(projectKey string, repo Repo, parms ParamsReposProjectDC, bitbucketURLBase string, importantBranches *[]ProjectBranch) error {
 
 	loggers := utils.NewLogger()
 	isEmpty, err := isRepositoryEmpty(projectKey, repo.Slug, parms.AccessToken, bitbucketURLBase, parms.APIVersion)
@@ -270,7 +262,7 @@ func processRepo(projectKey string, repo Repo, parms ParamsReposProjectDC, bitbu
 
 	loggers.Infof("\t   ✅ Repo: <%s> - Number of branches: %d", repo.Name, len(branches))
 
-	largestRepoSize, largestRepoBranch, err := findLargestBranch1(projectKey, repo.Slug, branches, parms, spin1)
+	largestRepoSize, largestRepoBranch, err := findLargestBranch1(projectKey, repo.Slug, branches, parms)
 	if err != nil {
 		return err
 	}
@@ -352,7 +344,7 @@ func GetRepos(project string, repos []Repo, parms ParamsReposDC, bitbucketURLBas
 	for _, repo := range repos {
 		isEmpty, err := isRepositoryEmpty(project, repo.Slug, parms.AccessToken, bitbucketURLBase, parms.APIVersion)
 		if err != nil {
-			logAndExit(fmt.Sprintf("❌ Error when testing if repo is empty %s: %v\n", repo.Name, err), parms.Spin)
+			logAndExit(fmt.Sprintf("❌ Error when testing if repo is empty %s: %v\n", repo.Name, err))
 		}
 
 		if isEmpty {
@@ -363,7 +355,7 @@ func GetRepos(project string, repos []Repo, parms ParamsReposDC, bitbucketURLBas
 
 		branches, err = getBranches(project, repo.Slug, parms)
 		if err != nil {
-			logAndExit(fmt.Sprintf("❌ Error when retrieving branches for repo %s: %v\n", repo.Name, err), parms.Spin)
+			logAndExit(fmt.Sprintf("❌ Error when retrieving branches for repo %s: %v\n", repo.Name, err))
 		}
 
 		fmt.Printf("\n\t   ✅ Repo: <%s> - Number of branches: %d\n", repo.Name, len(branches))
@@ -375,7 +367,7 @@ func GetRepos(project string, repos []Repo, parms ParamsReposDC, bitbucketURLBas
 
 		largestRepoSize, largestRepoBranch, err = findLargestBranch(project, repo.Slug, branches, parms)
 		if err != nil {
-			logAndExit(fmt.Sprintf("❌ Error retrieving branch size: %v\n", err), parms.Spin)
+			logAndExit(fmt.Sprintf("❌ Error retrieving branch size: %v\n", err))
 		}
 
 		fmt.Printf("\t     ✅ The largest branch of the repo is <%s> of size : %s\n", largestRepoBranch, utils.FormatSize(int64(largestRepoSize)))
@@ -392,18 +384,16 @@ func GetRepos(project string, repos []Repo, parms ParamsReposDC, bitbucketURLBas
 	result.ProjectBranches = importantBranches
 
 	if err := saveAnalysisResult(result); err != nil {
-		logAndExit(fmt.Sprintf("❌ Error creating Analysis file: %v\n", err), parms.Spin)
+		logAndExit(fmt.Sprintf("❌ Error creating Analysis file: %v\n", err))
 	}
 
 	return importantBranches, nbRepos, emptyRepo
 }
 
-func logAndExit(message string, spin *spinner.Spinner) {
+func logAndExit(message string) {
 	loggers := utils.NewLogger()
+	events.Finish("")
 	loggers.Errorln(message)
-	if spin != nil {
-		spin.Stop()
-	}
 	os.Exit(1)
 }
 
@@ -462,8 +452,7 @@ func findLargestBranch(project, repoSlug string, branches []Branch, parms Params
 	loggers := utils.NewLogger()
 
 	for _, branch := range branches {
-		parms.Spin.Prefix = fmt.Sprintf("\t   Analysis branch <%s> size...", branch.Name)
-		parms.Spin.Start()
+		events.Start(fmt.Sprintf("\t   Analysis branch <%s> size...", branch.Name))
 
 		Fetchparams := FetchParams{
 			ProjectKey:       project,
@@ -475,7 +464,7 @@ func findLargestBranch(project, repoSlug string, branches []Branch, parms Params
 		}
 
 		size, err := fetchBranchSize(Fetchparams)
-		parms.Spin.Stop()
+		events.Finish("")
 		if err != nil {
 			loggers.Errorf("❌ Error retrieving branch size:%v", err)
 			continue
@@ -490,13 +479,12 @@ func findLargestBranch(project, repoSlug string, branches []Branch, parms Params
 	return largestRepoSize, largestRepoBranch, nil
 }
 
-func findLargestBranch1(projectKey, repoSlug string, branches []Branch, parms ParamsReposProjectDC, spin1 *spinner.Spinner) (int, string, error) {
+func findLargestBranch1(projectKey, repoSlug string, branches []Branch, parms ParamsReposProjectDC) (int, string, error) {
 	var largestRepoSize int
 	var largestRepoBranch string
 
 	for _, branch := range branches {
-		spin1.Prefix = fmt.Sprintf("\t   Analysis branch <%s> size...", branch.Name)
-		spin1.Start()
+		events.Start(fmt.Sprintf("\t   Analysis branch <%s> size...", branch.Name))
 
 		Fetchparams := FetchParams{
 			ProjectKey:       projectKey,
@@ -508,7 +496,7 @@ func findLargestBranch1(projectKey, repoSlug string, branches []Branch, parms Pa
 		}
 
 		size, err := fetchBranchSize(Fetchparams)
-		spin1.Stop()
+		events.Finish("")
 		if err != nil {
 			return 0, "", fmt.Errorf("retrieving branch size: %w", err)
 		}
@@ -556,10 +544,6 @@ func GetProjectBitbucketList(platformConfig map[string]interface{}, exclusionFil
 
 	loggers.Infof("🔎 Analysis of devops platform objects ...")
 
-	spin := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin.Prefix = "Get Projects... "
-	spin.Color("green", "bold")
-
 	// Load Exclusion List
 	exclusionList, err = loadOrCreateExclusionList(exclusionFile)
 	if err != nil {
@@ -568,7 +552,7 @@ func GetProjectBitbucketList(platformConfig map[string]interface{}, exclusionFil
 	}
 
 	// Determine the Projects and Repos to Analyze
-	projects, repos, err := determineProjectsAndRepos(platformConfig, exclusionList, bitbucketURL, spin)
+	projects, repos, err := determineProjectsAndRepos(platformConfig, exclusionList, bitbucketURL)
 	if err != nil {
 		return nil, err
 	}
@@ -582,7 +566,6 @@ func GetProjectBitbucketList(platformConfig map[string]interface{}, exclusionFil
 			AccessToken:      platformConfig["AccessToken"].(string),
 			BitbucketURLBase: bitbucketURLBase,
 			ExclusionList:    exclusionList,
-			Spin:             spin,
 			Branch:           platformConfig["Branch"].(string),
 			DefaultB:         platformConfig["DefaultBranch"].(bool),
 		}
@@ -597,7 +580,6 @@ func GetProjectBitbucketList(platformConfig map[string]interface{}, exclusionFil
 			BitbucketURLBase: bitbucketURLBase,
 			ExclusionList:    exclusionList,
 			Branch:           platformConfig["Branch"].(string),
-			Spin:             spin,
 			DefaultB:         platformConfig["DefaultBranch"].(bool),
 		}
 		importantBranches, nbRepos, _ = GetRepos(platformConfig["Project"].(string), repos, parms, bitbucketURLBase, exclusionList)
@@ -605,7 +587,17 @@ func GetProjectBitbucketList(platformConfig map[string]interface{}, exclusionFil
 	}
 
 	// Summarize Analysis Results
-	return summarizeAnalysisResults(importantBranches, nbRepos), nil
+	importantBranches = summarizeAnalysisResults(importantBranches, nbRepos)
+	emitDiscovered(importantBranches)
+
+	return importantBranches, nil
+}
+
+// Send a RepoDiscovered event for each repository kept for the analysis
+func emitDiscovered(importantBranches []ProjectBranch) {
+	for _, branch := range importantBranches {
+		events.Emit(events.Event{Type: events.RepoDiscovered, Repository: branch.RepoSlug, Branch: branch.MainBranch})
+	}
 }
 
 func loadOrCreateExclusionList(exclusionFile string) (*utils.ExclusionList, error) {
@@ -618,7 +610,7 @@ func loadOrCreateExclusionList(exclusionFile string) (*utils.ExclusionList, erro
 	return utils.LoadExclusionList(exclusionFile)
 }
 
-func determineProjectsAndRepos(platformConfig map[string]interface{}, exclusionList *utils.ExclusionList, bitbucketURL string, spin *spinner.Spinner) ([]Project, []Repo, error) {
+func determineProjectsAndRepos(platformConfig map[string]interface{}, exclusionList *utils.ExclusionList, bitbucketURL string) ([]Project, []Repo, error) {
 	var projects []Project
 	var repos []Repo
 	var err error
@@ -627,69 +619,24 @@ func determineProjectsAndRepos(platformConfig map[string]interface{}, exclusionL
 	repo := platformConfig["Repos"].(string)
 
 	if project == "" && repo == "" {
-		spin.Start()
+		events.Start("Get Projects... ")
 		projects, err = fetchAllProjects(bitbucketURL, platformConfig["AccessToken"].(string), exclusionList)
-		spin.Stop()
+		events.Finish("")
 	} else if project != "" && repo == "" {
 		if isProjectExcluded1(project, *exclusionList) {
-<<<<<<< HEAD
-			fmt.Println("\n❌ Projet", project, "is excluded from the analysis... edit <.cloc_bitbucketdc_ignore> file")
-			os.Exit(1)
-		} else {
-
-			spin.Start()
-			bitbucketURLProject := fmt.Sprintf("%s/%s", bitbucketURL, project)
-
-			projects, err := fetchOnelProjects(bitbucketURLProject, accessToken, exclusionList)
-			if err != nil {
-				fmt.Printf("\n❌ Error Get Project:%s - %v", project, err)
-				spin.Stop()
-				return nil, err
-			}
-			spin.Stop()
-
-			if len(projects) == 0 {
-				fmt.Printf("\n❌ Error Project:%s not exist - %v", project, err)
-				spin.Stop()
-				return nil, err
-			} else {
-				importantBranches, nbRepos, emptyRepo = GetReposProject(projects, url, baseapi, apiver, accessToken, bitbucketURLBase, branchmain, nbRepos, exclusionList, spin)
-
-			}
-=======
 			return nil, nil, fmt.Errorf("project %s is excluded from the analysis", project)
->>>>>>> ver1.0.3
 		}
-		spin.Start()
+		events.Start("Get Projects... ")
 		projects, err = fetchOnelProjects(fmt.Sprintf("%s/%s", bitbucketURL, project), platformConfig["AccessToken"].(string), exclusionList)
-		spin.Stop()
+		events.Finish("")
 	} else if project != "" && repo != "" {
 		Texclude := project + "/" + repo
 		if isProjectAndRepoExcluded(Texclude, *exclusionList) {
-<<<<<<< HEAD
-			fmt.Println("\n❌ Projet ", project, "and the repository ", repo, "are excluded from the analysis...edit <.cloc_bitbucketdc_ignore> file")
-			os.Exit(1)
-		} else {
-
-			spin.Start()
-			bitbucketURLProject := fmt.Sprintf("%s/%s/repos/%s", bitbucketURL, project, repo)
-			Repos, err := fetchOneRepos(bitbucketURLProject, accessToken, exclusionList)
-			if err != nil {
-				fmt.Printf("\n❌ Error Get Repo:%s/%s - %v", project, repo, err)
-				spin.Stop()
-				return nil, err
-			}
-			fmt.Printf("Taille : %d", len(Repos))
-			spin.Stop()
-
-			importantBranches, nbRepos, emptyRepo = GetRepos(project, Repos, url, baseapi, apiver, accessToken, bitbucketURLBase, branchmain, exclusionList, spin)
-=======
 			return nil, nil, fmt.Errorf("project %s and repository %s are excluded from the analysis", project, repo)
->>>>>>> ver1.0.3
 		}
-		spin.Start()
+		events.Start("Get Projects... ")
 		repos, err = fetchOneRepos(fmt.Sprintf("%s/%s/repos/%s", bitbucketURL, project, repo), platformConfig["AccessToken"].(string), exclusionList)
-		spin.Stop()
+		events.Finish("")
 	} else {
 		return nil, nil, fmt.Errorf("project name is empty")
 	}
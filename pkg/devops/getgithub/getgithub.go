@@ -12,8 +12,8 @@ import (
 	"strings"
 	"time"
 
-	"github.com/briandowns/spinner"
 	"github.com/colussim/GoLC/assets"
+	"github.com/colussim/GoLC/pkg/events"
 	"github.com/colussim/GoLC/pkg/utils"
 	"github.com/google/go-github/v62/github"
 )
@@ -48,7 +48,6 @@ type ParamsReposGithub struct {
 	Organization  string
 	NBRepos       int
 	ExclusionList ExclusionRepos
-	Spin          *spinner.Spinner
 	Branch        string
 	Period        int
 	Stats         bool
@@ -280,8 +279,7 @@ func GetReposGithub(parms ParamsReposGithub, ctx context.Context, client *github
 	cpt = 1
 	loggers := utils.NewLogger()
 
-	spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin1.Color("green", "bold")
+	events.Finish("")
 
 	message4 := "Repo(s)"
 	//fmt.Printf("\t  ✅ The number of %s found is: %d\n", message4, parms.NBRepos)
@@ -305,7 +303,7 @@ func GetReposGithub(parms ParamsReposGithub, ctx context.Context, client *github
 			continue
 		}
 		if !isEmpty {
-			largestRepoBranch, repoBranches := analyzeRepoBranches(parms, ctx, client, repo, cpt, spin1)
+			largestRepoBranch, repoBranches := analyzeRepoBranches(parms, ctx, client, repo, cpt)
 			importantBranches = append(importantBranches, ProjectBranch{
 				Org:         parms.Organization,
 				RepoSlug:    repoName,
@@ -332,7 +330,7 @@ func GetReposGithub(parms ParamsReposGithub, ctx context.Context, client *github
 	return importantBranches, emptyRepo, parms.NBRepos, TotalBranches, notAnalyzedCount, cptarchiv
 }
 
-func analyzeRepoBranches(parms ParamsReposGithub, ctx context.Context, client *github.Client, repo *github.Repository, cpt int, spin1 *spinner.Spinner) (string, []*github.Branch) {
+func analyzeRepoBranches(parms ParamsReposGithub, ctx context.Context, client *github.Client, repo *github.Repository, cpt int) (string, []*github.Branch) {
 	var branches []*github.Branch
 	var allEvents []*github.Event
 	var branchPushes map[string]*BranchInfoEvents
@@ -343,8 +341,7 @@ func analyzeRepoBranches(parms ParamsReposGithub, ctx context.Context, client *g
 	}
 
 	messageB := fmt.Sprintf("\t   Analysis top branch(es) in repository <%s> ...", *repo.Name)
-	spin1.Prefix = messageB
-	spin1.Start()
+	events.Start(messageB)
 
 	var largestRepoBranch string
 	var err error
@@ -371,7 +368,7 @@ func analyzeRepoBranches(parms ParamsReposGithub, ctx context.Context, client *g
 			if err != nil {
 				//fmt.Printf("❌ Error when retrieving branches for repo %v: %v\n", *repo.Name, err)
 				loggers.Errorf("❌ Error when retrieving branches for repo %v: %v\n", *repo.Name, err)
-				spin1.Stop()
+				events.Finish("")
 				return "", nil
 			}
 			largestRepoBranch = determineLargestBranch(parms, repo, branchPushes)
@@ -383,7 +380,7 @@ func analyzeRepoBranches(parms ParamsReposGithub, ctx context.Context, client *g
 		if err != nil {
 			//fmt.Printf("❌ Error when retrieving branches for repo %v: %v\n", *repo.Name, err)
 			loggers.Errorf("❌ Error when retrieving branches for repo %v: %v\n", *repo.Name, err)
-			spin1.Stop()
+			events.Finish("")
 			return "", nil
 		}
 		largestRepoBranch = determineLargestBranch(parms, repo, branchPushes)
@@ -394,14 +391,14 @@ func analyzeRepoBranches(parms ParamsReposGithub, ctx context.Context, client *g
 	if err != nil {
 		//	fmt.Println("❌ Error fetching repository events:", err)
 		loggers.Errorf("❌ Error fetching repository events:", err)
-		spin1.Stop()
+		events.Finish("")
 		return "", nil
 	}
 
 	branchPushes = countBranchPushes(allEvents, parms.Period)
 	analyzeBranches(ctx, client, parms, *repo.Name, branchPushes)
 
-	spin1.Stop()
+	events.Finish("")
 
 	//fmt.Printf("\r\t\t✅ %d Repo: %s - Number of branches: %d - largest Branch: %s \n", cpt, *repo.Name, nbrbranche, largestRepoBranch)
 	loggers.Infof("\r\t\t\t\t✅ %d Repo: %s - Number of branches: %d - largest Branch: %s ", cpt, *repo.Name, nbrbranche, largestRepoBranch)
@@ -594,12 +591,9 @@ func GetRepoGithubList(platformConfig map[string]interface{}, exclusionfile stri
 	//fmt.Print("\n🔎 Analysis of devops platform objects ...\n")
 	loggers.Infof("🔎 Analysis of devops platform objects ...\n")
 
-	spin := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin.Prefix = PrefixMsg
-	spin.Color("green", "bold")
-	spin.Start()
+	events.Start(PrefixMsg)
 
-	exclusionList, err1 = loadExclusionFile(exclusionfile, spin)
+	exclusionList, err1 = loadExclusionFile(exclusionfile)
 	if err1 != nil {
 		return nil, err1
 	}
@@ -613,10 +607,11 @@ func GetRepoGithubList(platformConfig map[string]interface{}, exclusionfile stri
 	}
 
 	if err1 != nil {
+		events.Finish("")
 		return importantBranches, nil
 	}
 
-	params := getCommonParams(platformConfig, repositories, exclusionList, spin)
+	params := getCommonParams(platformConfig, repositories, exclusionList)
 	sortRepositoriesByUpdatedAt(repositories)
 
 	if err := SaveRepos(repositories); err != nil {
@@ -643,11 +638,19 @@ func GetRepoGithubList(platformConfig map[string]interface{}, exclusionfile stri
 	}
 
 	printSummary(config, stats)
+	emitDiscovered(importantBranches)
 
 	return importantBranches, nil
 }
 
-func loadExclusionFile(exclusionfile string, spin *spinner.Spinner) (ExclusionRepos, error) {
+// Send a RepoDiscovered event for each repository kept for the analysis
+func emitDiscovered(importantBranches []ProjectBranch) {
+	for _, branch := range importantBranches {
+		events.Emit(events.Event{Type: events.RepoDiscovered, Repository: branch.RepoSlug, Branch: branch.MainBranch})
+	}
+}
+
+func loadExclusionFile(exclusionfile string) (ExclusionRepos, error) {
 	var exclusionList ExclusionRepos
 	var err error
 	loggers := utils.NewLogger()
@@ -658,7 +661,7 @@ func loadExclusionFile(exclusionfile string, spin *spinner.Spinner) (ExclusionRe
 		exclusionList, err = loadExclusionRepos1(exclusionfile)
 		if err != nil {
 			loggers.Errorf("\n❌ Error Read Exclusion File <%s>: %v", exclusionfile, err)
-			spin.Stop()
+			events.Finish("")
 			return nil, err
 		}
 	}
@@ -699,7 +702,7 @@ func fetchSingleRepository(ctx context.Context, client *github.Client, platformC
 	return []*github.Repository{repos}, nil
 }
 
-func getCommonParams(platformConfig map[string]interface{}, repositories []*github.Repository, exclusionList ExclusionRepos, spin *spinner.Spinner) ParamsReposGithub {
+func getCommonParams(platformConfig map[string]interface{}, repositories []*github.Repository, exclusionList ExclusionRepos) ParamsReposGithub {
 	return ParamsReposGithub{
 		Repos:         repositories,
 		URL:           platformConfig["Url"].(string),
@@ -709,7 +712,6 @@ func getCommonParams(platformConfig map[string]interface{}, repositories []*gith
 		Organization:  platformConfig["Organization"].(string),
 		NBRepos:       len(repositories),
 		ExclusionList: exclusionList,
-		Spin:          spin,
 		Branch:        platformConfig["Branch"].(string),
 		Period:        int(platformConfig["Period"].(float64)),
 		Stats:         platformConfig["Stats"].(bool),
@@ -763,10 +765,7 @@ func FastAnalys(platformConfig map[string]interface{}, exlusionfile string) erro
 	//fmt.Print("\n🔎 Analysis of devops platform objects ...\n")
 	loggers.Infof("🔎 Analysis of devops platform objects ...\n")
 
-	spin := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin.Prefix = PrefixMsg
-	spin.Color("green", "bold")
-	spin.Start()
+	events.Start(PrefixMsg)
 
 	// Test if exclusion file exist
 	if exlusionfile == "0" {
@@ -776,7 +775,7 @@ func FastAnalys(platformConfig map[string]interface{}, exlusionfile string) erro
 		exclusionList, err1 = loadExclusionRepos1(exlusionfile)
 		if err1 != nil {
 			loggers.Errorf("❌ Error Read Exclusion File <%s>: %v", exlusionfile, err1)
-			spin.Stop()
+			events.Finish("")
 			//return nil, err1
 		}
 
@@ -814,7 +813,6 @@ func FastAnalys(platformConfig map[string]interface{}, exlusionfile string) erro
 			Organization:  platformConfig["Organization"].(string),
 			NBRepos:       len(repositories),
 			ExclusionList: exclusionList,
-			Spin:          spin,
 			Branch:        platformConfig["Branch"].(string),
 			Period:        int(platformConfig["Period"].(float64)),
 			Stats:         platformConfig["Stats"].(bool),
@@ -855,7 +853,6 @@ func FastAnalys(platformConfig map[string]interface{}, exlusionfile string) erro
 			Organization:  platformConfig["Organization"].(string),
 			NBRepos:       len(repositories),
 			ExclusionList: exclusionList,
-			Spin:          spin,
 			Branch:        platformConfig["Branch"].(string),
 			Period:        int(platformConfig["Period"].(float64)),
 			Stats:         platformConfig["Stats"].(bool),
@@ -877,9 +874,7 @@ func GetGithubLanguages(parms ParamsReposGithub, ctx context.Context, client *gi
 	cptarchiv := 0        // Counter archiv repos
 	notAnalyzedCount := 0 // Counter Number of repositories excluded
 	emptyRepo := 0        // Counter Number of repositories empty
-	parms.Spin.Stop()
-	spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin1.Color("green", "bold")
+	events.Finish("")
 
 	message4 := "Repo(s)"
 	fmt.Printf("\t  ✅ The number of %s found is: %d\n", message4, parms.NBRepos)
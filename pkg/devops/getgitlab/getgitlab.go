@@ -8,7 +8,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/briandowns/spinner"
+	"github.com/colussim/GoLC/pkg/events"
 	"github.com/colussim/GoLC/pkg/utils"
 	"github.com/xanzy/go-gitlab"
 )
@@ -34,7 +34,6 @@ type AnalyzeProject struct {
 	Project       *gitlab.Project
 	GitlabClient  *gitlab.Client
 	ExclusionList ExclusionRepos
-	Spin1         *spinner.Spinner
 	Org           string
 }
 
@@ -312,8 +311,7 @@ func analyzeProj(analyzeProject AnalyzeProject) (ProjectBranch, int, int, int) {
 	}
 
 	messageB := fmt.Sprintf(Message2, analyzeProject.Project.Name)
-	analyzeProject.Spin1.Prefix = messageB
-	analyzeProject.Spin1.Start()
+	events.Start(messageB)
 	// Retrieve project branches
 
 	largestBranch := analyzeProject.Project.DefaultBranch
@@ -331,7 +329,7 @@ func analyzeProj(analyzeProject AnalyzeProject) (ProjectBranch, int, int, int) {
 
 }
 
-func processProject(analyzeProject AnalyzeProject, cpt int, spin1 *spinner.Spinner, projectBranches []ProjectBranch, emptyRepos, archivedRepos, excludedProjects *int) ([]ProjectBranch, int) {
+func processProject(analyzeProject AnalyzeProject, cpt int, projectBranches []ProjectBranch, emptyRepos, archivedRepos, excludedProjects *int) ([]ProjectBranch, int) {
 	projectBranche, ExcludedProject, EmptyRepos, ArchivedRepos := analyzeProj(analyzeProject)
 
 	loggers := utils.NewLogger()
@@ -350,7 +348,7 @@ func processProject(analyzeProject AnalyzeProject, cpt int, spin1 *spinner.Spinn
 	}
 
 	projectBranches = append(projectBranches, projectBranche)
-	spin1.Stop()
+	events.Finish("")
 	loggers.Infof(Message3, cpt, analyzeProject.Project.Name, 1, projectBranche.MainBranch)
 	cpt++
 	return projectBranches, cpt
@@ -402,10 +400,7 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 
 	loggers.Infof("🔎 Analysis of devops platform objects ...\n")
 
-	spin := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin.Prefix = PrefixMsg
-	spin.Color("green", "bold")
-	spin.Start()
+	events.Start(PrefixMsg)
 
 	// Test if exclusion file exist
 	if exclusionfile == "0" {
@@ -416,7 +411,7 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 		exclusionList, err1 = LoadExclusionRepos(exclusionfile)
 		if err1 != nil {
 			loggers.Errorf("❌ Error Read Exclusion File <%s>: %v", exclusionfile, err1)
-			spin.Stop()
+			events.Finish("")
 			return nil, err1
 		}
 
@@ -440,9 +435,7 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 				loggers.Fatalf(MessageErro1, platformConfig["Organization"].(string), err)
 			}
 
-			spin.Stop()
-			spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-			spin1.Color("green", "bold")
+			events.Finish("")
 
 			loggers.Infof(Message1, Message4, len(projects))
 
@@ -454,20 +447,17 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 					Project:       project,
 					GitlabClient:  gitlabClient,
 					ExclusionList: exclusionList,
-					Spin1:         spin1,
 					Org:           platformConfig["Organization"].(string),
 				}
 
-				projectBranches, cpt = processProject(parmsproject, cpt, spin1, projectBranches, &emptyRepos, &archivedRepos, &excludedProjects)
+				projectBranches, cpt = processProject(parmsproject, cpt, projectBranches, &emptyRepos, &archivedRepos, &excludedProjects)
 				TotalBranches++
 			}
 			/* --------------------- End Analysis all projects with a default branche  ---------------------  */
 		} else {
 			/* --------------------- Analysis a specific projects with a default branche  ---------------------  */
 			cpt := 1
-			spin.Stop()
-			spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-			spin1.Color("green", "bold")
+			events.Finish("")
 			//	largestSize := 0
 
 			namespase := platformConfig["Organization"].(string) + "/" + platformConfig["Project"].(string)
@@ -482,11 +472,10 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 				Project:       project,
 				GitlabClient:  gitlabClient,
 				ExclusionList: exclusionList,
-				Spin1:         spin1,
 				Org:           platformConfig["Organization"].(string),
 			}
 
-			projectBranches, _ = processProject(parmsproject, cpt, spin1, projectBranches, &emptyRepos, &archivedRepos, &excludedProjects)
+			projectBranches, _ = processProject(parmsproject, cpt, projectBranches, &emptyRepos, &archivedRepos, &excludedProjects)
 			TotalBranches++
 
 		}
@@ -503,17 +492,15 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 
 			projects, err := getAllGroupProjects(gitlabClient, platformConfig["Organization"].(string))
 			if err != nil {
-				spin.Stop()
+				events.Finish("")
 				log.Fatalf(MessageErro1, platformConfig["Organization"].(string), err)
 			}
 
-			spin.Stop()
-			spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-			spin1.Color("green", "bold")
+			events.Finish("")
 
 			fmt.Printf(Message1, Message4, len(projects))*/
 
-			projects, cpt, spin1, err := getProjectsAndAnalyze(gitlabClient, platformConfig["Organization"].(string), spin)
+			projects, cpt, err := getProjectsAndAnalyze(gitlabClient, platformConfig["Organization"].(string))
 			if err != nil {
 				loggers.Fatalf(err.Error())
 			}
@@ -532,12 +519,11 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 				}
 
 				messageB := fmt.Sprintf(Message2, project.Name)
-				spin1.Prefix = messageB
-				spin1.Start()
+				events.Start(messageB)
 
 				mainBranch, largestSize, nbrsize, err := getMainBranchDetails(gitlabClient, project, since, until)
 				if err != nil {
-					spin1.Stop()
+					events.Finish("")
 					loggers.Fatalf(err.Error())
 				}
 
@@ -550,7 +536,7 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 				})
 				TotalRepoBranches = nbrsize
 
-				spin1.Stop()
+				events.Finish("")
 				loggers.Infof(Message3, cpt, project.Name, nbrsize, mainBranch)
 				cpt++
 				TotalBranches += TotalRepoBranches
@@ -563,9 +549,7 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 
 		case platformConfig["Project"].(string) != "" && platformConfig["Branch"].(string) == "":
 
-			spin.Stop()
-			spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-			spin1.Color("green", "bold")
+			events.Finish("")
 
 			namespase := platformConfig["Organization"].(string) + "/" + platformConfig["Project"].(string)
 
@@ -581,15 +565,14 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 			}
 
 			messageB := fmt.Sprintf("\t   Analysis top branch(es) in repository <%s> ...", project.Name)
-			spin1.Prefix = messageB
-			spin1.Start()
+			events.Start(messageB)
 
 			mainBranch, largestSize, nbrsize, err := getMainBranchDetails(gitlabClient, project, since, until)
 			if err != nil {
 				loggers.Fatalf("\n ❌ Failed to get main branch for project %s: %v\n", platformConfig["Project"].(string), err)
 			}
 
-			spin1.Stop()
+			events.Finish("")
 			loggers.Infof("\r\t\t✅ 1 Project: %s - Number of branches: %d - largest Branch: %s", project.Name, nbrsize, mainBranch)
 
 			projectBranches = append(projectBranches, ProjectBranch{
@@ -646,17 +629,15 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 
 			projects, err := getAllGroupProjects(gitlabClient, platformConfig["Organization"].(string))
 			if err != nil {
-				spin.Stop()
+				events.Finish("")
 				log.Fatalf(MessageErro1, platformConfig["Organization"].(string), err)
 			}
 
-			spin.Stop()
-			spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-			spin1.Color("green", "bold")
+			events.Finish("")
 
 			fmt.Printf(Message1, Message4, len(projects))*/
 
-			projects, cpt, spin1, err := getProjectsAndAnalyze(gitlabClient, platformConfig["Organization"].(string), spin)
+			projects, cpt, err := getProjectsAndAnalyze(gitlabClient, platformConfig["Organization"].(string))
 			if err != nil {
 				loggers.Fatalf(err.Error())
 			}
@@ -673,12 +654,11 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 					continue
 				}
 				messageB := fmt.Sprintf(Message2, project.Name)
-				spin1.Prefix = messageB
-				spin1.Start()
+				events.Start(messageB)
 
 				largestBranch := platformConfig["Branch"].(string)
 				if !branchExists(gitlabClient, project.ID, largestBranch) {
-					spin1.Stop()
+					events.Finish("")
 					continue
 				}
 
@@ -692,7 +672,7 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 					LargestSize: largestSize,
 				})
 
-				spin1.Stop()
+				events.Finish("")
 				loggers.Infof(Message3, cpt, project.Name, 1, largestBranch)
 				cpt++
 				TotalBranches++
@@ -732,25 +712,31 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 	loggers.Infof("✅ The largest Repository is <%s> in the Organizationa <%s> with the branch <%s>", largesRepo, platformConfig["Organization"].(string), largestRepoBranch)
 	loggers.Infof("✅ TotalProject(s) that will be analyzed: %d - Find empty : %d - Excluded : %d - Archived : %d", len(projectBranches), emptyRepos, excludedProjects, archivedRepos)
 	loggers.Infof("✅ Total Branches that will be analyzed: %d\n", TotalBranches)
+	emitDiscovered(projectBranches)
 	return projectBranches, nil
 }
 
-func getProjectsAndAnalyze(gitlabClient *gitlab.Client, organization string, spin *spinner.Spinner) ([]*gitlab.Project, int, *spinner.Spinner, error) {
+// Send a RepoDiscovered event for each repository kept for the analysis
+func emitDiscovered(projectBranches []ProjectBranch) {
+	for _, branch := range projectBranches {
+		events.Emit(events.Event{Type: events.RepoDiscovered, Repository: branch.RepoSlug, Branch: branch.MainBranch})
+	}
+}
+
+func getProjectsAndAnalyze(gitlabClient *gitlab.Client, organization string) ([]*gitlab.Project, int, error) {
 
 	cpt := 1
 	loggers := utils.NewLogger()
 
 	projects, err := getAllGroupProjects(gitlabClient, organization)
 	if err != nil {
-		spin.Stop()
+		events.Finish("")
 		loggers.Fatalf(MessageErro1, organization, err)
 	}
 
-	spin.Stop()
-	spin1 := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin1.Color("green", "bold")
+	events.Finish("")
 
 	loggers.Infof(Message1, Message4, len(projects))
 
-	return projects, cpt, spin1, nil
+	return projects, cpt, nil
 }
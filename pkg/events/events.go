@@ -0,0 +1,120 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Type names what happened.
+type Type string
+
+const (
+	// Started and Finished frame a step of the analysis, such as the
+	// listing of the repositories of a platform.
+	Started  Type = "started"
+	Finished Type = "finished"
+	// RepoDiscovered is sent for each repository, with its branch, that
+	// a platform listing keeps for the analysis.
+	RepoDiscovered Type = "repo-discovered"
+	CloneStarted   Type = "clone-started"
+	CloneDone      Type = "clone-done"
+	// FileScanned is sent after each file, with the number of files
+	// scanned so far out of the total of the repository.
+	FileScanned Type = "file-scanned"
+	// RepoDone is sent at the end of the analysis of a repository, with
+	// its totals or its error.
+	RepoDone Type = "repo-done"
+)
+
+type Event struct {
+	Type       Type      `json:"type"`
+	Time       time.Time `json:"time"`
+	Repository string    `json:"repository,omitempty"`
+	Branch     string    `json:"branch,omitempty"`
+	Path       string    `json:"path,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	Files      int       `json:"files,omitempty"`
+	Total      int       `json:"total,omitempty"`
+	Lines      int       `json:"lines,omitempty"`
+	CodeLines  int       `json:"codeLines,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Observer receives the events of the analysis. Events are sent from
+// concurrent goroutines, so implementations must be safe for concurrent
+// use.
+type Observer interface {
+	Notify(event Event)
+}
+
+// Quiet drops every event.
+type Quiet struct{}
+
+func (Quiet) Notify(Event) {}
+
+var (
+	observer      Observer = Quiet{}
+	observerMutex sync.RWMutex
+)
+
+// SetObserver replaces the default observer, which drops the events, so
+// that the programs embedding the packages draw nothing unless they ask
+// for it, with a TTY for instance.
+func SetObserver(o Observer) {
+	observerMutex.Lock()
+	defer observerMutex.Unlock()
+	observer = o
+}
+
+// Default returns the default observer.
+func Default() Observer {
+	observerMutex.RLock()
+	defer observerMutex.RUnlock()
+	return observer
+}
+
+// Notify sends the event to o, or to the default observer when o is nil,
+// and stamps it with the current time.
+func Notify(o Observer, event Event) {
+	if o == nil {
+		o = Default()
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	o.Notify(event)
+}
+
+// Emit sends the event to the default observer.
+func Emit(event Event) {
+	Notify(nil, event)
+}
+
+// Start sends a Started event to the default observer.
+func Start(message string) {
+	Emit(Event{Type: Started, Message: message})
+}
+
+// Finish sends a Finished event to the default observer, its message, if
+// any, is the outcome of the step.
+func Finish(message string) {
+	Emit(Event{Type: Finished, Message: message})
+}
+
+// ForRepository returns an observer that sends the events to o, or to the
+// default observer, with their Repository set to repository.
+func ForRepository(o Observer, repository string) Observer {
+	return repositoryObserver{observer: o, repository: repository}
+}
+
+type repositoryObserver struct {
+	observer   Observer
+	repository string
+}
+
+func (r repositoryObserver) Notify(event Event) {
+	if event.Repository == "" {
+		event.Repository = r.repository
+	}
+	Notify(r.observer, event)
+}
@@ -0,0 +1,105 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder keeps the events it receives.
+type recorder struct {
+	events []Event
+	mutex  sync.Mutex
+}
+
+func (r *recorder) Notify(event Event) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = append(r.events, event)
+}
+
+func TestJSONLines(t *testing.T) {
+	var out bytes.Buffer
+	j := NewJSONLines(&out)
+
+	at := time.Date(2024, 7, 11, 17, 23, 35, 0, time.UTC)
+	j.Notify(Event{Type: CloneStarted, Time: at, Repository: "jenkins-docker", Branch: "main"})
+	j.Notify(Event{Type: RepoDone, Time: at, Repository: "jenkins-docker", Files: 42, Lines: 3120, CodeLines: 2480})
+	j.Notify(Event{Type: RepoDone, Time: at, Repository: "broken", Error: "clone failed"})
+
+	want := `{"type":"clone-started","time":"2024-07-11T17:23:35Z","repository":"jenkins-docker","branch":"main"}
+{"type":"repo-done","time":"2024-07-11T17:23:35Z","repository":"jenkins-docker","files":42,"lines":3120,"codeLines":2480}
+{"type":"repo-done","time":"2024-07-11T17:23:35Z","repository":"broken","error":"clone failed"}
+`
+	if out.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestJSONLinesConcurrent(t *testing.T) {
+	var out bytes.Buffer
+	j := NewJSONLines(&out)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 1; n <= 100; n++ {
+				Notify(j, Event{Type: FileScanned, Files: n, Total: 100})
+			}
+		}()
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 800 {
+		t.Fatalf("got %d lines, want 800", len(lines))
+	}
+	for _, line := range lines {
+		var event Event
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("line %q: %v", line, err)
+		}
+		if event.Type != FileScanned || event.Time.IsZero() {
+			t.Fatalf("got %+v", event)
+		}
+	}
+}
+
+func TestDefaultObserver(t *testing.T) {
+	if _, ok := Default().(Quiet); !ok {
+		t.Fatalf("the default observer is %T, want Quiet", Default())
+	}
+
+	r := &recorder{}
+	SetObserver(r)
+	defer SetObserver(Quiet{})
+
+	Start("listing")
+	Notify(ForRepository(nil, "repo"), Event{Type: CloneStarted})
+	Notify(ForRepository(nil, "repo"), Event{Type: CloneDone, Repository: "other"})
+	Finish("done")
+
+	want := []Event{
+		{Type: Started, Message: "listing"},
+		{Type: CloneStarted, Repository: "repo"},
+		{Type: CloneDone, Repository: "other"},
+		{Type: Finished, Message: "done"},
+	}
+	if len(r.events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(r.events), len(want))
+	}
+	for i, event := range r.events {
+		if event.Time.IsZero() {
+			t.Errorf("event %d has no time", i)
+		}
+		event.Time = time.Time{}
+		if event != want[i] {
+			t.Errorf("event %d: got %+v, want %+v", i, event, want[i])
+		}
+	}
+}
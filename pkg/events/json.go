@@ -0,0 +1,24 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// JSONLines writes each event as a line of JSON, for CI logs and other
+// tools.
+type JSONLines struct {
+	encoder *json.Encoder
+	mutex   sync.Mutex
+}
+
+func NewJSONLines(w io.Writer) *JSONLines {
+	return &JSONLines{encoder: json.NewEncoder(w)}
+}
+
+func (j *JSONLines) Notify(event Event) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.encoder.Encode(event)
+}
@@ -0,0 +1,95 @@
+package events
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/briandowns/spinner"
+)
+
+// TTY renders the events on a terminal with a single spinner, whose line
+// shows the current step and the repositories being cloned or scanned.
+// Nothing is drawn when the output is not a terminal.
+type TTY struct {
+	writer  io.Writer
+	spinner *spinner.Spinner
+	step    string
+	repos   map[string]string
+	mutex   sync.Mutex
+}
+
+func NewTTY(w io.Writer) *TTY {
+	s := spinner.New(spinner.CharSets[35], 100*time.Millisecond, spinner.WithWriter(w))
+	s.Color("green", "bold")
+
+	return &TTY{
+		writer:  w,
+		spinner: s,
+		repos:   map[string]string{},
+	}
+}
+
+func (t *TTY) Notify(event Event) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	switch event.Type {
+	case Started:
+		t.step = event.Message
+	case Finished:
+		t.step = ""
+		if event.Message != "" {
+			t.spinner.Stop()
+			fmt.Fprint(t.writer, event.Message)
+		}
+	case CloneStarted:
+		t.repos[event.Repository] = "extracting files"
+	case CloneDone:
+		t.repos[event.Repository] = "scanning files"
+	case FileScanned:
+		t.repos[event.Repository] = fmt.Sprintf("scanning files %d/%d", event.Files, event.Total)
+	case RepoDone:
+		delete(t.repos, event.Repository)
+	default:
+		return
+	}
+
+	t.render()
+}
+
+func (t *TTY) render() {
+	status := t.status()
+	if status == "" {
+		t.spinner.Stop()
+		return
+	}
+
+	t.spinner.Lock()
+	t.spinner.Suffix = " " + status
+	t.spinner.Unlock()
+	t.spinner.Start()
+}
+
+// status is the step, or the first repository in name order followed by
+// the number of the other ones.
+func (t *TTY) status() string {
+	if t.step != "" || len(t.repos) == 0 {
+		return t.step
+	}
+
+	names := make([]string, 0, len(t.repos))
+	for name := range t.repos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	status := fmt.Sprintf("%s : %s", names[0], t.repos[names[0]])
+	if len(names) > 1 {
+		status += fmt.Sprintf(" (+%d repositories)", len(names)-1)
+	}
+
+	return status
+}
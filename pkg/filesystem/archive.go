@@ -0,0 +1,110 @@
+package filesystem
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+var archiveExtensions = []string{".zip", ".tar", ".tar.gz", ".tgz"}
+
+// maxTarSize caps the bytes of the files of a tar archive, which are kept
+// in memory. Larger archives have to be extracted before the analysis.
+const maxTarSize = 1 << 30
+
+// IsArchive tells whether the path names a zip or tar(.gz) archive.
+func IsArchive(path string) bool {
+	lower := strings.ToLower(path)
+	for _, extension := range archiveExtensions {
+		if strings.HasSuffix(lower, extension) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ArchiveSource returns the source of a zip or tar(.gz) archive, whose
+// files are read in place. The files of a tar archive are kept in memory,
+// since it cannot be read at random.
+func ArchiveSource(archive string) (*Source, error) {
+	if strings.HasSuffix(strings.ToLower(archive), ".zip") {
+		reader, err := zip.OpenReader(archive)
+		if err != nil {
+			return nil, err
+		}
+		return &Source{Root: archive, FS: reader, closer: reader}, nil
+	}
+
+	f, err := os.Open(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if !strings.HasSuffix(strings.ToLower(archive), ".tar") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	tarFS, err := readTar(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Source{Root: archive, FS: tarFS}, nil
+}
+
+// readTar loads a tar archive in memory, up to maxTarSize bytes. Only
+// regular files and directories are kept, and the last copy of a file
+// appended several times wins, as with tar -x.
+func readTar(r io.Reader) (*MemFS, error) {
+	m := NewMemFS()
+	var total int64
+
+	reader := tar.NewReader(r)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "/"))
+		if name == "." || !fs.ValidPath(name) {
+			continue
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			m.AddDir(name, header.ModTime)
+		case tar.TypeReg:
+			total += header.Size
+			if total > maxTarSize {
+				return nil, fmt.Errorf("the files of the archive exceed %d MiB, extract it first", maxTarSize>>20)
+			}
+			data, err := io.ReadAll(reader)
+			if err != nil {
+				return nil, err
+			}
+			m.AddFile(name, fs.FileMode(header.Mode), header.ModTime, int64(len(data)), func() ([]byte, error) {
+				return data, nil
+			})
+		}
+	}
+
+	return m, nil
+}
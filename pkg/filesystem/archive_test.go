@@ -0,0 +1,49 @@
+package filesystem
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/fs"
+	"testing"
+)
+
+func TestReadTarKeepsLastDuplicate(t *testing.T) {
+	var archive bytes.Buffer
+	writer := tar.NewWriter(&archive)
+	for _, content := range []string{"first\n", "second copy\n"} {
+		header := &tar.Header{Name: "dir/file.go", Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := writer.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writer.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := readTar(&archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := fs.ReadFile(m, "dir/file.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "second copy\n" {
+		t.Errorf("got %q, want the last copy", data)
+	}
+
+	entries, err := fs.ReadDir(m, "dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	if info, _ := entries[0].Info(); info.Size() != int64(len("second copy\n")) {
+		t.Errorf("got size %d, want the size of the last copy", info.Size())
+	}
+}
@@ -1,19 +1,182 @@
 package filesystem
 
 import (
+	"bufio"
+	"io"
+	"path"
 	"path/filepath"
+	"strings"
 )
 
-func GetExcludePaths(path string, excludePathsParam []string) ([]string, error) {
-	var excludeDirs []string
+// Matcher excludes paths with gitignore-like patterns:
+//   - a pattern without a slash, such as *_test.go or vendor, matches a file
+//     or a directory name at any depth
+//   - a pattern with a slash is relative to the root, and ** matches any
+//     number of directories, as in **/test/** or src/**/*.pb.go
+//   - a trailing slash only matches directories
+//   - a leading ! includes again the paths excluded by previous patterns
+//
+// A path is excluded when the last pattern matching it, or one of its
+// parent directories, is not a negation.
+type Matcher struct {
+	root     string
+	patterns []pattern
+	negation bool
+}
+
+type pattern struct {
+	segments []string
+	negate   bool
+	dirOnly  bool
+}
+
+// NewMatcher compiles the patterns relative to root. Blank lines and lines
+// starting with # are ignored, so that pattern files can be commented.
+func NewMatcher(root string, patterns []string) (*Matcher, error) {
+	m := &Matcher{root: root}
+
+	for _, line := range patterns {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		p := pattern{}
+		if strings.HasPrefix(line, "!") {
+			p.negate = true
+			line = line[1:]
+		}
+		line = strings.TrimPrefix(line, "\\")
+		if strings.HasSuffix(line, "/") {
+			p.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(filepath.ToSlash(line), "/")
+		if line == "" {
+			continue
+		}
+		if _, err := path.Match(line, ""); err != nil {
+			return nil, err
+		}
+
+		p.segments = strings.Split(line, "/")
+		if !anchored {
+			p.segments = append([]string{"**"}, p.segments...)
+		}
+
+		m.patterns = append(m.patterns, p)
+		m.negation = m.negation || p.negate
+	}
+
+	return m, nil
+}
+
+// Anchor makes the patterns without a slash relative to the root, as the
+// path exclusions were before they followed the gitignore syntax: vendor
+// then only leaves out the vendor directory of the root.
+func Anchor(patterns []string) []string {
+	anchored := make([]string, 0, len(patterns))
+
+	for _, line := range patterns {
+		pattern := strings.TrimSpace(line)
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			anchored = append(anchored, line)
+			continue
+		}
+
+		prefix := "/"
+		if strings.HasPrefix(pattern, "!") {
+			prefix = "!/"
+			pattern = pattern[1:]
+		}
+		pattern = strings.TrimPrefix(pattern, "\\")
+		if strings.Contains(strings.TrimRight(filepath.ToSlash(pattern), "/"), "/") {
+			anchored = append(anchored, line)
+			continue
+		}
+
+		anchored = append(anchored, prefix+pattern)
+	}
+
+	return anchored
+}
+
+// ReadPatterns reads a pattern file, one pattern per line.
+func ReadPatterns(r io.Reader) ([]string, error) {
+	var patterns []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		patterns = append(patterns, scanner.Text())
+	}
+
+	return patterns, scanner.Err()
+}
+
+// Match tells whether the path is excluded.
+func (m *Matcher) Match(path string, isDir bool) bool {
+	excluded, _ := m.Lookup(path, isDir)
+	return excluded
+}
 
-	for _, dir := range excludePathsParam {
-		matches, err := filepath.Glob(filepath.Join(path, dir))
-		if err != nil {
-			return []string{}, err
+// Lookup tells whether the path is excluded, and whether any pattern
+// matches it at all, so that the matcher can override the decision of
+// another one.
+func (m *Matcher) Lookup(path string, isDir bool) (bool, bool) {
+	rel, err := filepath.Rel(m.root, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false, false
+	}
+	components := strings.Split(filepath.ToSlash(rel), "/")
+
+	excluded, matched := false, false
+	for _, p := range m.patterns {
+		if p.match(components, isDir) {
+			excluded, matched = !p.negate, true
 		}
-		excludeDirs = append(excludeDirs, matches...)
 	}
 
-	return excludeDirs, nil
+	return excluded, matched
+}
+
+// SkipDir tells whether a directory can be left out as a whole, which is
+// only safe when no negation could include again some of its files.
+func (m *Matcher) SkipDir(dir string) bool {
+	return !m.negation && m.Match(dir, true)
+}
+
+func (p pattern) match(components []string, isDir bool) bool {
+	for i := len(components); i > 0; i-- {
+		if p.dirOnly && i == len(components) && !isDir {
+			continue
+		}
+		if matchSegments(p.segments, components[:i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchSegments(segments, components []string) bool {
+	if len(segments) == 0 {
+		return len(components) == 0
+	}
+
+	if segments[0] == "**" {
+		if matchSegments(segments[1:], components) {
+			return true
+		}
+		return len(components) > 0 && matchSegments(segments, components[1:])
+	}
+
+	if len(components) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(segments[0], components[0]); !ok {
+		return false
+	}
+
+	return matchSegments(segments[1:], components[1:])
 }
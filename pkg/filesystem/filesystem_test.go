@@ -0,0 +1,69 @@
+package filesystem
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMatcher(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		isDir    bool
+		want     bool
+	}{
+		{name: "name at the root", patterns: []string{"vendor"}, path: "vendor/lib/a.go", want: true},
+		{name: "name at any depth", patterns: []string{"vendor"}, path: "src/vendor/a.go", want: true},
+		{name: "pattern with a slash is anchored", patterns: []string{"src/gen"}, path: "lib/src/gen/a.go", want: false},
+		{name: "double star", patterns: []string{"**/test/**"}, path: "a/b/test/c/d.go", want: true},
+		{name: "glob on the name", patterns: []string{"*_test.go"}, path: "pkg/a_test.go", want: true},
+		{name: "directory only", patterns: []string{"build/"}, path: "build", isDir: false, want: false},
+		{name: "negation", patterns: []string{"vendor/", "!vendor/keep/**"}, path: "vendor/keep/a.go", want: false},
+		{name: "comment", patterns: []string{"# vendor"}, path: "vendor/a.go", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := NewMatcher(root, test.patterns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := m.Match(filepath.Join(root, filepath.FromSlash(test.path)), test.isDir); got != test.want {
+				t.Errorf("Match(%s) = %v, want %v", test.path, got, test.want)
+			}
+		})
+	}
+}
+
+// The former path exclusions were globbed from the root, Anchor keeps
+// them relative to it.
+func TestAnchor(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "vendor/lib/a.go", want: true},
+		{path: "src/vendor/a.go", want: false},
+		{path: "main_test.go", want: true},
+		{path: "pkg/a_test.go", want: false},
+		{path: "docs/api/index.md", want: true},
+		{path: "docs/api/keep.md", want: false},
+		{path: "web/docs/api/index.md", want: false},
+	}
+
+	m, err := NewMatcher(root, Anchor([]string{"vendor", "*_test.go", "docs/api", "!docs/api/keep.md", "# comment", ""}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		if got := m.Match(filepath.Join(root, filepath.FromSlash(test.path)), false); got != test.want {
+			t.Errorf("Match(%s) = %v, want %v", test.path, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package filesystem
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"time"
+)
+
+// MemFS is a read-only fs.FS whose tree is indexed in memory and whose
+// file contents are loaded when the files are opened. It serves the
+// sources that are not extracted to disk, such as tar archives or git
+// trees. Files can be opened concurrently, so the loaders must be safe for
+// concurrent use.
+type MemFS struct {
+	entries map[string]*memEntry
+	dirs    map[string][]fs.DirEntry
+}
+
+type memEntry struct {
+	name    string
+	mode    fs.FileMode
+	modTime time.Time
+	size    int64
+	load    func() ([]byte, error)
+}
+
+func NewMemFS() *MemFS {
+	m := &MemFS{
+		entries: map[string]*memEntry{},
+		dirs:    map[string][]fs.DirEntry{},
+	}
+	m.add(&memEntry{name: ".", mode: fs.ModeDir | 0555})
+
+	return m
+}
+
+// AddDir adds a directory and its missing parents.
+func (m *MemFS) AddDir(name string, modTime time.Time) {
+	if _, ok := m.entries[name]; ok {
+		return
+	}
+
+	m.AddDir(path.Dir(name), modTime)
+	m.add(&memEntry{name: name, mode: fs.ModeDir | 0555, modTime: modTime})
+}
+
+// AddFile adds a file of size bytes, read by load, and its missing parent
+// directories. Names are slash-separated and relative to the root. A file
+// added again replaces the previous one.
+func (m *MemFS) AddFile(name string, mode fs.FileMode, modTime time.Time, size int64, load func() ([]byte, error)) {
+	m.AddDir(path.Dir(name), modTime)
+	m.add(&memEntry{name: name, mode: mode.Perm(), modTime: modTime, size: size, load: load})
+}
+
+func (m *MemFS) add(entry *memEntry) {
+	if previous, ok := m.entries[entry.name]; ok {
+		if !previous.IsDir() && !entry.IsDir() {
+			*previous = *entry
+		}
+		return
+	}
+
+	m.entries[entry.name] = entry
+	if entry.name != "." {
+		parent := path.Dir(entry.name)
+		m.dirs[parent] = append(m.dirs[parent], entry)
+	}
+}
+
+func (m *MemFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	entry, ok := m.entries[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	if entry.IsDir() {
+		entries, _ := m.ReadDir(name)
+		return &memDir{entry: entry, entries: entries}, nil
+	}
+
+	data, err := entry.load()
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return &memFile{entry: entry, reader: bytes.NewReader(data)}, nil
+}
+
+// ReadDir lists a directory in name order.
+func (m *MemFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entry, ok := m.entries[name]
+	if !ok || !entry.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	entries := append([]fs.DirEntry(nil), m.dirs[name]...)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	return entries, nil
+}
+
+// memEntry is both the fs.FileInfo and the fs.DirEntry of its file.
+func (e *memEntry) Name() string               { return path.Base(e.name) }
+func (e *memEntry) Size() int64                { return e.size }
+func (e *memEntry) Mode() fs.FileMode          { return e.mode }
+func (e *memEntry) ModTime() time.Time         { return e.modTime }
+func (e *memEntry) IsDir() bool                { return e.mode.IsDir() }
+func (e *memEntry) Sys() any                   { return nil }
+func (e *memEntry) Type() fs.FileMode          { return e.mode.Type() }
+func (e *memEntry) Info() (fs.FileInfo, error) { return e, nil }
+
+type memFile struct {
+	entry  *memEntry
+	reader *bytes.Reader
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.entry, nil }
+func (f *memFile) Read(b []byte) (int, error) { return f.reader.Read(b) }
+func (f *memFile) Close() error               { return nil }
+
+type memDir struct {
+	entry   *memEntry
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *memDir) Stat() (fs.FileInfo, error) { return d.entry, nil }
+func (d *memDir) Close() error               { return nil }
+
+func (d *memDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.entry.name, Err: fs.ErrInvalid}
+}
+
+func (d *memDir) ReadDir(count int) ([]fs.DirEntry, error) {
+	entries := d.entries[d.offset:]
+	if count > 0 && len(entries) > count {
+		entries = entries[:count]
+	}
+	d.offset += len(entries)
+
+	if count > 0 && len(entries) == 0 {
+		return nil, io.EOF
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,63 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestMemFS(t *testing.T) {
+	files := map[string]string{
+		"README.md":       "# title\n",
+		"src/main.go":     "package main\n",
+		"src/lib/util.go": "package lib\n",
+	}
+
+	m := NewMemFS()
+	m.AddDir("empty", time.Time{})
+	for name, content := range files {
+		data := []byte(content)
+		m.AddFile(name, 0o644, time.Time{}, int64(len(data)), func() ([]byte, error) {
+			return data, nil
+		})
+	}
+
+	if err := fstest.TestFS(m, "README.md", "src/main.go", "src/lib/util.go", "empty"); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name, content := range files {
+				data, err := fs.ReadFile(m, name)
+				if err != nil || string(data) != content {
+					t.Errorf("%s: got %q, %v, want %q", name, data, err, content)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestMemFSLoadError(t *testing.T) {
+	m := NewMemFS()
+	failure := errors.New("blob is missing")
+	m.AddFile("a.go", 0o644, time.Time{}, 1, func() ([]byte, error) {
+		return nil, failure
+	})
+
+	_, err := m.Open("a.go")
+	var pathErr *fs.PathError
+	if !errors.As(err, &pathErr) || pathErr.Path != "a.go" || !errors.Is(err, failure) {
+		t.Errorf("got %v, want the load error of a.go", err)
+	}
+	if _, err := m.Open("missing.go"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("got %v, want fs.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,104 @@
+package filesystem
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Source is the tree of files to analyze. Files are named by their path on
+// disk, or by the path of the archive followed by their path inside it,
+// and FS serves them relative to Root. A Source without FS is read from
+// disk.
+type Source struct {
+	Root   string
+	FS     fs.FS
+	closer io.Closer
+}
+
+// DirSource returns the source of a directory on disk.
+func DirSource(root string) *Source {
+	return &Source{Root: root}
+}
+
+// Open opens a file of the source.
+func (s *Source) Open(path string) (fs.File, error) {
+	if s.FS == nil {
+		return os.Open(path)
+	}
+
+	name, err := s.name(path, "open")
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := s.FS.Open(name)
+	if pathErr, ok := err.(*fs.PathError); ok {
+		pathErr.Path = path
+	}
+
+	return f, err
+}
+
+// ReadDir lists a directory of the source.
+func (s *Source) ReadDir(dir string) ([]fs.DirEntry, error) {
+	if s.FS == nil {
+		return os.ReadDir(dir)
+	}
+
+	name, err := s.name(dir, "readdir")
+	if err != nil {
+		return nil, err
+	}
+
+	return fs.ReadDir(s.FS, name)
+}
+
+// WalkDir walks the source from its root, like filepath.WalkDir.
+func (s *Source) WalkDir(fn fs.WalkDirFunc) error {
+	return s.WalkDirFrom(s.Root, fn)
+}
+
+// WalkDirFrom walks the directory dir of the source.
+func (s *Source) WalkDirFrom(dir string, fn fs.WalkDirFunc) error {
+	if s.FS == nil {
+		return filepath.WalkDir(dir, fn)
+	}
+
+	name, err := s.name(dir, "walk")
+	if err != nil {
+		return err
+	}
+
+	return fs.WalkDir(s.FS, name, func(name string, d fs.DirEntry, err error) error {
+		return fn(s.path(name), d, err)
+	})
+}
+
+// Close releases the archive of the source.
+func (s *Source) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+
+	return s.closer.Close()
+}
+
+func (s *Source) name(path string, op string) (string, error) {
+	rel, err := filepath.Rel(s.Root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", &fs.PathError{Op: op, Path: path, Err: fs.ErrNotExist}
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+func (s *Source) path(name string) string {
+	if name == "." {
+		return s.Root
+	}
+
+	return filepath.Join(s.Root, filepath.FromSlash(name))
+}
@@ -1,34 +1,19 @@
 package getter
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
-	"time"
 
-	"github.com/briandowns/spinner"
 	getter "github.com/hashicorp/go-getter"
 )
 
-func extractLastString(url string) string {
-	/*parts := strings.Split(url, "/")
-	return parts[len(parts)-1]*/
-
-	return filepath.Base(url)
-}
-
-func Getter(src string) (string, error) {
-	RepoString := extractLastString(src)
-
-	spinner := newSpinner(fmt.Sprintf("\r Extracting files from %s \n", RepoString))
-	spinner.Color("green", "bold")
-	messageF := ""
-	spinner.FinalMSG = messageF
-	spinner.Start()
-	defer spinner.Stop()
-
+// Getter downloads or links src into a temporary directory. The download
+// stops when ctx is done, and the directory is then removed.
+func Getter(ctx context.Context, src string) (string, error) {
 	suffix, err := randomSuffix()
 	if err != nil {
 		return "", err
@@ -41,6 +26,7 @@ func Getter(src string) (string, error) {
 	}
 
 	client := &getter.Client{
+		Ctx: ctx,
 		Src: src,
 		Dst: dst,
 		Pwd: pwd,
@@ -49,6 +35,10 @@ func Getter(src string) (string, error) {
 	}
 
 	if err := client.Get(); err != nil {
+		os.RemoveAll(dst)
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
 		return "", err
 	}
 
@@ -58,7 +48,9 @@ func Getter(src string) (string, error) {
 	}
 
 	if symLink {
+		// Local directories are linked to, the link itself is not needed.
 		origin, err := os.Readlink(dst)
+		os.Remove(dst)
 		if err != nil {
 			return "", err
 		}
@@ -69,14 +61,6 @@ func Getter(src string) (string, error) {
 	return dst, nil
 }
 
-func newSpinner(text string) *spinner.Spinner {
-	return spinner.New(
-		spinner.CharSets[35],
-		100*time.Millisecond,
-		spinner.WithSuffix(text),
-	)
-}
-
 func randomSuffix() (string, error) {
 	randBytes := make([]byte, 16)
 	_, err := rand.Read(randBytes)
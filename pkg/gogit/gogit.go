@@ -1,6 +1,7 @@
 package gogit
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
@@ -18,7 +19,9 @@ import (
 	//"github.com/go-git/go-git/v5/plumbing/transport/http"
 )
 
-func Getrepos(src, branch, token string) (string, error) {
+// Getrepos clones the branch of src into a temporary directory. The clone
+// stops when ctx is done, and the directory is then removed.
+func Getrepos(ctx context.Context, src, branch, token string) (string, error) {
 
 	loggers := utils.NewLogger()
 	suffix, err := randomSuffix()
@@ -37,7 +40,7 @@ func Getrepos(src, branch, token string) (string, error) {
 		capability.ThinPack,
 	}
 
-	_, err = git.PlainClone(dst, false, &git.CloneOptions{
+	_, err = git.PlainCloneContext(ctx, dst, false, &git.CloneOptions{
 		URL: src,
 
 		ReferenceName: plumbing.NewBranchReferenceName(branch),
@@ -47,12 +50,18 @@ func Getrepos(src, branch, token string) (string, error) {
 		Depth:        1,
 	})
 
+	if ctx.Err() != nil {
+		os.RemoveAll(dst)
+		return "", ctx.Err()
+	}
+
 	if err != nil {
+		os.RemoveAll(dst)
 		re := regexp.MustCompile(`(https?:\/\/)[^@]+(@)`)
 		maskedSrc := re.ReplaceAllString(src, "${1}*****${2}")
 		//fmt.Printf("\n--❌ Stack: gogit.Getrepos Git Branch %s - %s-- Source: %s -", plumbing.Main, err, maskedSrc)
 		loggers.Errorf("\r\t\t\t\t❌ Stack: gogit.Getrepos Git Branch %s - %s-- Source: %s -", plumbing.Main, err, maskedSrc)
-
+		return "", fmt.Errorf("%s: %w", maskedSrc, err)
 	}
 
 	symLink, err := isSymLink(dst)
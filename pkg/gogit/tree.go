@@ -0,0 +1,180 @@
+package gogit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/colussim/GoLC/pkg/filesystem"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// TreeSource returns the source of the branch of src, or of its HEAD when
+// branch is empty, read from the git objects without checking out a
+// working tree. A local repository, bare or
+// not, is opened in place, any other src is cloned in memory. The files
+// are named after the repository, as in <repository>/<path>. Symbolic
+// links and submodules are left out. As for Getrepos, the credentials are
+// those of the URL of src.
+func TreeSource(ctx context.Context, src, branch string) (*filesystem.Source, error) {
+	repo, err := openRepository(ctx, src, branch)
+	if err != nil {
+		re := regexp.MustCompile(`(https?:\/\/)[^@]+(@)`)
+		maskedSrc := re.ReplaceAllString(src, "${1}*****${2}")
+		return nil, fmt.Errorf("%s: %w", maskedSrc, err)
+	}
+
+	commit, err := branchCommit(repo, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	memFS, err := readTree(ctx, repo, tree, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filesystem.Source{Root: repositoryName(src), FS: memFS}, nil
+}
+
+// IsRepository tells whether path is a local git repository, bare or not.
+func IsRepository(path string) bool {
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return false
+	}
+	_, err := git.PlainOpen(path)
+
+	return err == nil
+}
+
+func openRepository(ctx context.Context, src, branch string) (*git.Repository, error) {
+	if info, err := os.Stat(src); err == nil && info.IsDir() {
+		return git.PlainOpen(src)
+	}
+
+	transport.UnsupportedCapabilities = []capability.Capability{
+		capability.ThinPack,
+	}
+
+	options := &git.CloneOptions{
+		URL:          src,
+		SingleBranch: true,
+		Depth:        1,
+		NoCheckout:   true,
+	}
+	if branch != "" {
+		options.ReferenceName = plumbing.NewBranchReferenceName(branch)
+	}
+
+	return git.CloneContext(ctx, memory.NewStorage(), nil, options)
+}
+
+// branchCommit resolves the branch, or HEAD when no branch is given.
+func branchCommit(repo *git.Repository, branch string) (*object.Commit, error) {
+	var ref *plumbing.Reference
+	var err error
+	if branch == "" {
+		ref, err = repo.Head()
+	} else {
+		ref, err = repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.CommitObject(ref.Hash())
+}
+
+// readTree indexes the tree of the commit, the blobs are only read when
+// their file is opened. The blobs of a repository opened on disk share the
+// handles of its packfiles and are read one at a time, those of an
+// in-memory clone are read concurrently.
+func readTree(ctx context.Context, repo *git.Repository, tree *object.Tree, commit *object.Commit) (*filesystem.MemFS, error) {
+	memFS := filesystem.NewMemFS()
+	modTime := commit.Committer.When
+
+	var mutex sync.Mutex
+	_, inMemory := repo.Storer.(*memory.Storage)
+
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch entry.Mode {
+		case filemode.Dir:
+			memFS.AddDir(name, modTime)
+		case filemode.Regular, filemode.Deprecated, filemode.Executable:
+			size, err := repo.Storer.EncodedObjectSize(entry.Hash)
+			if err != nil {
+				return nil, err
+			}
+			hash := entry.Hash
+			mode, _ := entry.Mode.ToOSFileMode()
+			memFS.AddFile(name, fs.FileMode(mode), modTime, size, func() ([]byte, error) {
+				if !inMemory {
+					mutex.Lock()
+					defer mutex.Unlock()
+				}
+				return readBlob(repo, hash)
+			})
+		}
+	}
+
+	return memFS, nil
+}
+
+func readBlob(repo *git.Repository, hash plumbing.Hash) ([]byte, error) {
+	blob, err := object.GetBlob(repo.Storer, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// repositoryName is the last element of the URL or path of the
+// repository, without its .git suffix.
+func repositoryName(src string) string {
+	name := path.Base(strings.TrimRight(strings.ReplaceAll(src, "\\", "/"), "/"))
+	if name == "." || name == "/" {
+		return "repository"
+	}
+
+	return strings.TrimSuffix(name, ".git")
+}
@@ -0,0 +1,175 @@
+package gogit
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+var treeFiles = map[string]string{
+	"README.md":       "# sample\n",
+	"main.go":         "package main\n\nfunc main() {}\n",
+	"pkg/lib/util.go": "package lib\n",
+}
+
+// commitFiles writes the files to the worktree and commits them.
+func commitFiles(t *testing.T, repo *git.Repository, worktree billy.Filesystem, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		if err := worktree.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		f, err := worktree.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AddGlob("."); err != nil {
+		t.Fatal(err)
+	}
+	signature := &object.Signature{Name: "test", Email: "test@example.com", When: time.Unix(1700000000, 0)}
+	if _, err := w.Commit("files", &git.CommitOptions{Author: signature, Committer: signature}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// checkTree reads every file of fsys and compares them with want.
+func checkTree(t *testing.T, fsys fs.FS, want map[string]string) {
+	t.Helper()
+
+	var names []string
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		names = append(names, name)
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		if string(data) != want[name] {
+			t.Errorf("%s: got %q, want %q", name, data, want[name])
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(names)
+	if len(names) != len(want) {
+		t.Errorf("got files %v, want %d files", names, len(want))
+	}
+}
+
+func TestReadTreeInMemory(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, _ := repo.Worktree()
+	commitFiles(t, repo, worktree.Filesystem, treeFiles)
+
+	commit, err := branchCommit(repo, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	memFS, err := readTree(context.Background(), repo, tree, commit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTree(t, memFS, treeFiles)
+
+	info, err := fs.Stat(memFS, "main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != int64(len(treeFiles["main.go"])) || !info.ModTime().Equal(commit.Committer.When) {
+		t.Errorf("got size %d and time %v", info.Size(), info.ModTime())
+	}
+}
+
+func TestTreeSource(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "sample")
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, _ := repo.Worktree()
+	commitFiles(t, repo, worktree.Filesystem, treeFiles)
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	branch := head.Name().Short()
+
+	// The files of the worktree that are not committed are left out.
+	if err := os.WriteFile(filepath.Join(dir, "untracked.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	bare := filepath.Join(t.TempDir(), "bare.git")
+	if _, err := git.PlainClone(bare, true, &git.CloneOptions{URL: dir}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		src    string
+		branch string
+		root   string
+	}{
+		{name: "head of a local repository", src: dir, root: "sample"},
+		{name: "branch of a local repository", src: dir, branch: branch, root: "sample"},
+		{name: "bare repository", src: bare, root: "bare"},
+		{name: "clone in memory", src: "file://" + filepath.ToSlash(dir), branch: branch, root: "sample"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source, err := TreeSource(context.Background(), test.src, test.branch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer source.Close()
+
+			if source.Root != test.root {
+				t.Errorf("got root %s, want %s", source.Root, test.root)
+			}
+			checkTree(t, source.FS, treeFiles)
+		})
+	}
+
+	if _, err := TreeSource(context.Background(), dir, "missing"); err == nil {
+		t.Error("a missing branch did not fail")
+	}
+	if !IsRepository(dir) || !IsRepository(bare) || IsRepository(t.TempDir()) {
+		t.Error("IsRepository does not tell repositories apart")
+	}
+}
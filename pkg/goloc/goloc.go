@@ -1,10 +1,17 @@
 package goloc
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/colussim/GoLC/pkg/analyzer"
+	"github.com/colussim/GoLC/pkg/events"
 	"github.com/colussim/GoLC/pkg/filesystem"
 	"github.com/colussim/GoLC/pkg/getter"
 	"github.com/colussim/GoLC/pkg/gogit"
@@ -15,26 +22,47 @@ import (
 	"github.com/colussim/GoLC/pkg/scanner"
 	"github.com/colussim/GoLC/pkg/sorter"
 	"github.com/colussim/GoLC/pkg/utils"
+	"github.com/sirupsen/logrus"
 )
 
 type Params struct {
-	Path              string
-	ByFile            bool
-	ExcludePaths      []string
-	ExcludeExtensions []string
-	IncludeExtensions []string
-	OrderByLang       bool
-	OrderByFile       bool
-	OrderByCode       bool
-	OrderByLine       bool
-	OrderByBlank      bool
-	OrderByComment    bool
-	Order             string
-	OutputName        string
-	OutputPath        string
-	ReportFormats     []string
-	Branch            string
-	Token             string
+	Path               string
+	ByFile             bool
+	ExcludePaths       []string
+	ExcludePatterns    []string
+	ExcludeFile        string
+	ExcludeExtensions  []string
+	IncludeExtensions  []string
+	OrderByLang        bool
+	OrderByFile        bool
+	OrderByCode        bool
+	OrderByLine        bool
+	OrderByBlank       bool
+	OrderByComment     bool
+	Order              string
+	OutputName         string
+	OutputPath         string
+	ReportFormats      []string
+	Branch             string
+	Token              string
+	ScanWorkers        int
+	SkipBinary         bool
+	SkipMinified       bool
+	SkipGenerated      bool
+	MinifiedLineLength int
+	GeneratedMarkers   []string
+	Strict             bool
+	GitIgnore          bool
+	ExclusionProfiles  map[string][]string
+	TestPatterns       []string
+	InMemory           bool
+	// Name is the repository of the events, the last element of Path by
+	// default.
+	Name string
+	// Observer receives the events of the analysis, the default observer
+	// is used if it is not set.
+	Observer events.Observer
+	Logger   *logrus.Logger
 }
 
 type GCloc struct {
@@ -43,25 +71,74 @@ type GCloc struct {
 	scanner   *scanner.Scanner
 	sorter    sorter.Sorter
 	reporters []reporter.Reporter
-	Repopath  string
+	source    *filesystem.Source
+	observer  events.Observer
+	// Repopath is the directory of the repository on disk, it is empty
+	// when the repository is read in memory.
+	Repopath string
 }
 
-func NewGCloc(params Params, languages language.Languages) (*GCloc, error) {
+// NewGCloc fetches the repository of params.Path, the clone or download
+// stops when ctx is done. If the analysis cannot be set up, the clone or
+// download is removed and the source is closed before returning.
+func NewGCloc(ctx context.Context, params Params, languages language.Languages) (gc *GCloc, err error) {
 	var path string
-	var err error
 	loggers := utils.NewLogger()
 
-	if len(params.Branch) != 0 {
-		path, err = gogit.Getrepos(params.Path, params.Branch, params.Token)
+	var source *filesystem.Source
+	repoPath := ""
+
+	name := params.Name
+	if name == "" {
+		name = filepath.Base(strings.TrimRight(params.Path, "/"))
+	}
+	observer := events.ForRepository(params.Observer, name)
+
+	events.Notify(observer, events.Event{Type: events.CloneStarted, Branch: params.Branch})
+	defer func() {
+		if err != nil {
+			if repoPath != "" && isTemporary(repoPath) {
+				os.RemoveAll(repoPath)
+			}
+			if source != nil {
+				source.Close()
+			}
+			events.Notify(observer, events.Event{Type: events.RepoDone, Error: err.Error()})
+		}
+	}()
+
+	if params.InMemory && (len(params.Branch) != 0 || gogit.IsRepository(params.Path)) {
+		// The branch, or the HEAD of a local repository, is read from the
+		// git objects, without a checkout.
+		source, err = gogit.TreeSource(ctx, params.Path, params.Branch)
+		if err != nil {
+			return nil, err
+		}
+		path = source.Root
+		if len(params.Branch) == 0 {
+			params.OutputName = fmt.Sprintf("%s%s", params.OutputName, path)
+		}
+	} else if len(params.Branch) != 0 {
+		path, err = gogit.Getrepos(ctx, params.Path, params.Branch, params.Token)
 		if err != nil {
 			return nil, err
 			//fmt.Println(err)
 		}
+		repoPath = path
+	} else if isLocalArchive(params.Path) {
+		// Archives are read in place instead of being extracted.
+		path = params.Path
+		source, err = filesystem.ArchiveSource(path)
+		if err != nil {
+			return nil, err
+		}
+		params.OutputName = fmt.Sprintf("%s%s", params.OutputName, filepath.Base(path))
 	} else {
-		path, err = getter.Getter(params.Path)
+		path, err = getter.Getter(ctx, params.Path)
 		if err != nil {
 			return nil, err
 		}
+		repoPath = path
 
 		lastPart := filepath.Base(path)
 		if lastPart != "" {
@@ -82,20 +159,44 @@ func NewGCloc(params Params, languages language.Languages) (*GCloc, error) {
 
 			}*/
 	}
-	excludePaths, err := filesystem.GetExcludePaths(path, params.ExcludePaths)
+	events.Notify(observer, events.Event{Type: events.CloneDone, Branch: params.Branch})
+
+	exclude, err := excludeMatcher(path, source, params)
 	if err != nil {
 		return nil, err
 	}
 
-	analyzer := analyzer.NewAnalyzer(
+	analyzer, err := analyzer.NewAnalyzer(
 		path,
-		excludePaths,
+		exclude,
 		utils.ConvertToMap(params.ExcludeExtensions),
 		utils.ConvertToMap(params.IncludeExtensions),
-		getExtensionsMap(languages),
+		languages,
+		analyzer.ContentFilter{
+			SkipBinary:         params.SkipBinary,
+			SkipMinified:       params.SkipMinified,
+			SkipGenerated:      params.SkipGenerated,
+			MinifiedLineLength: params.MinifiedLineLength,
+			GeneratedMarkers:   params.GeneratedMarkers,
+		},
 	)
+	if err != nil {
+		return nil, err
+	}
+	analyzer.Logger = params.Logger
+	analyzer.Source = source
+	analyzer.Strict = params.Strict
+	analyzer.GitIgnore = params.GitIgnore
+	analyzer.TestPatterns = params.TestPatterns
+	analyzer.Profiles, err = exclusionProfiles(path, params.ExclusionProfiles)
+	if err != nil {
+		return nil, err
+	}
 
-	scanner := scanner.NewScanner(languages)
+	scanner := scanner.NewScanner(languages, params.ScanWorkers)
+	scanner.Strict = params.Strict
+	scanner.Source = source
+	scanner.Observer = observer
 
 	sorter := getSorter(params.ByFile, params.Order)
 
@@ -107,32 +208,83 @@ func NewGCloc(params Params, languages language.Languages) (*GCloc, error) {
 		scanner:   scanner,
 		sorter:    sorter,
 		reporters: reporters,
-		Repopath:  path,
+		source:    source,
+		observer:  observer,
+		Repopath:  repoPath,
 	}, nil
 }
 
-func (gc *GCloc) Run() error {
-	files, err := gc.analyzer.MatchingFiles()
+// Close releases the archive or the git objects read by the analysis, if
+// any.
+func (gc *GCloc) Close() error {
+	if gc.source == nil {
+		return nil
+	}
+
+	return gc.source.Close()
+}
+
+// Run analyzes the repository and returns its sorted results. The reports
+// of ReportFormats are written too, none is written if it is empty. The
+// analysis stops when ctx is done.
+func (gc *GCloc) Run(ctx context.Context) (*sorter.SortedSummary, error) {
+	summary, err := gc.Analyze(ctx)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	sortedSummary := gc.sortSummary(summary)
+	if err := gc.generateReports(sortedSummary); err != nil {
+		return sortedSummary, err
 	}
 
-	scanResult, err := gc.scanner.Scan(files)
+	return sortedSummary, nil
+}
+
+// Analyze scans the repository and returns its results, without sorting
+// them or writing any report.
+func (gc *GCloc) Analyze(ctx context.Context) (*scanner.Summary, error) {
+	summary, err := gc.analyze(ctx)
 	if err != nil {
-		return err
+		events.Notify(gc.observer, events.Event{Type: events.RepoDone, Error: err.Error()})
+		return nil, err
 	}
 
-	summary := gc.scanner.Summary(scanResult)
+	events.Notify(gc.observer, events.Event{
+		Type:      events.RepoDone,
+		Files:     summary.TotalFiles,
+		Lines:     summary.TotalLines,
+		CodeLines: summary.TotalCodeLines,
+	})
 
-	sortedSummary := gc.sortSummary(summary)
+	return summary, nil
+}
+
+func (gc *GCloc) analyze(ctx context.Context) (*scanner.Summary, error) {
+	files, err := gc.analyzer.MatchingFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := scanner.NewSummary(gc.params.ByFile)
+	if err := gc.scanner.Scan(ctx, files, summary); err != nil {
+		return nil, err
+	}
 
-	return gc.generateReports(sortedSummary)
+	summary.SkippedFiles = gc.analyzer.SkippedFiles
+	summary.Errors = append(gc.analyzer.Errors, gc.scanner.Errors...)
+	summary.Excluded = gc.analyzer.Excluded
+
+	return summary, nil
 }
 
-func (gc *GCloc) ChangeLanguages(languages language.Languages) {
-	extensions := getExtensionsMap(languages)
+func (gc *GCloc) ChangeLanguages(languages language.Languages) error {
+	if err := gc.analyzer.SetLanguages(languages); err != nil {
+		return err
+	}
 	gc.scanner.SupportedLanguages = languages
-	gc.analyzer.SupportedExtensions = extensions
+
+	return nil
 }
 
 func (gc *GCloc) sortSummary(summary *scanner.Summary) *sorter.SortedSummary {
@@ -186,16 +338,71 @@ func (gc *GCloc) generateReports(sortedSummary *sorter.SortedSummary) error {
 	return nil
 }
 
-func getExtensionsMap(languages language.Languages) map[string]string {
-	extensions := map[string]string{}
+// isTemporary tells whether path is a clone or download made for the
+// analysis, rather than a local directory it was linked to.
+func isTemporary(path string) bool {
+	return filepath.Dir(path) == filepath.Clean(os.TempDir()) && strings.HasPrefix(filepath.Base(path), "gcloc-extract-")
+}
+
+// isLocalArchive tells whether path is a zip or tar(.gz) file on disk.
+func isLocalArchive(path string) bool {
+	if !filesystem.IsArchive(path) {
+		return false
+	}
+	info, err := os.Stat(path)
+
+	return err == nil && info.Mode().IsRegular()
+}
+
+// excludeMatcher combines the ExcludePaths and ExcludePatterns patterns
+// with the ones of the ExcludeFile of the repository, when it has one. The
+// ExcludePaths keep their former meaning, relative to the root.
+func excludeMatcher(path string, source *filesystem.Source, params Params) (*filesystem.Matcher, error) {
+	patterns := append(filesystem.Anchor(params.ExcludePaths), params.ExcludePatterns...)
+
+	if params.ExcludeFile != "" {
+		if source == nil {
+			source = filesystem.DirSource(path)
+		}
+		filePatterns, err := readExcludeFile(source, filepath.Join(path, params.ExcludeFile))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		patterns = append(append([]string{}, patterns...), filePatterns...)
+	}
+
+	return filesystem.NewMatcher(path, patterns)
+}
+
+func readExcludeFile(source *filesystem.Source, file string) ([]string, error) {
+	f, err := source.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
 
-	for language, languageInfo := range languages {
-		for _, extension := range languageInfo.Extensions {
-			extensions[extension] = language
+	return filesystem.ReadPatterns(f)
+}
+
+// exclusionProfiles compiles the patterns of each profile, in the order of
+// their names.
+func exclusionProfiles(path string, profiles map[string][]string) ([]analyzer.Profile, error) {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var compiled []analyzer.Profile
+	for _, name := range names {
+		matcher, err := filesystem.NewMatcher(path, profiles[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern in the %s exclusion profile: %v", name, err)
 		}
+		compiled = append(compiled, analyzer.Profile{Name: name, Matcher: matcher})
 	}
 
-	return extensions
+	return compiled, nil
 }
 
 func getSorter(byFile bool, order string) sorter.Sorter {
@@ -0,0 +1,125 @@
+package goloc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/colussim/GoLC/assets"
+	"github.com/colussim/GoLC/pkg/events"
+)
+
+// recorder keeps the events it receives.
+type recorder struct {
+	events []events.Event
+	mutex  sync.Mutex
+}
+
+func (r *recorder) Notify(event events.Event) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = append(r.events, event)
+}
+
+// inLogDir runs the test from a temporary directory holding the Logs
+// directory of the loggers.
+func inLogDir(t *testing.T) {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "Logs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestRunEvents(t *testing.T) {
+	inLogDir(t)
+
+	repo := filepath.Join(t.TempDir(), "sample")
+	for name, content := range map[string]string{
+		"main.go":     "package main\n\n// main does nothing.\nfunc main() {}\n",
+		"lib/util.go": "package lib\n",
+		"README.md":   "# sample\n",
+	} {
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &recorder{}
+	gc, err := NewGCloc(context.Background(), Params{Path: repo, Observer: r, ScanWorkers: 2}, assets.Languages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gc.Close()
+
+	summary, err := gc.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := []events.Type{events.CloneStarted, events.CloneDone}
+	for i := 0; i < summary.TotalFiles; i++ {
+		types = append(types, events.FileScanned)
+	}
+	types = append(types, events.RepoDone)
+
+	if len(r.events) != len(types) {
+		t.Fatalf("got %d events, want %d: %+v", len(r.events), len(types), r.events)
+	}
+	for i, event := range r.events {
+		if event.Type != types[i] || event.Repository != "sample" {
+			t.Errorf("event %d: got %s of %q, want %s of sample", i, event.Type, event.Repository, types[i])
+		}
+		if event.Type == events.FileScanned && (event.Files != i-1 || event.Total != summary.TotalFiles) {
+			t.Errorf("event %d: got file %d/%d, want %d/%d", i, event.Files, event.Total, i-1, summary.TotalFiles)
+		}
+	}
+
+	done := r.events[len(r.events)-1]
+	if done.Files != summary.TotalFiles || done.CodeLines != summary.TotalCodeLines || done.Error != "" {
+		t.Errorf("got %+v, want the totals of the summary", done)
+	}
+}
+
+func TestNewGClocErrorEvents(t *testing.T) {
+	inLogDir(t)
+
+	r := &recorder{}
+	_, err := NewGCloc(context.Background(), Params{
+		Path:         t.TempDir(),
+		Name:         "broken",
+		Observer:     r,
+		ExcludePaths: []string{"["},
+	}, assets.Languages)
+	if err == nil {
+		t.Fatal("an invalid exclusion pattern did not fail")
+	}
+
+	types := []events.Type{events.CloneStarted, events.CloneDone, events.RepoDone}
+	if len(r.events) != len(types) {
+		t.Fatalf("got %d events, want %d: %+v", len(r.events), len(types), r.events)
+	}
+	for i, event := range r.events {
+		if event.Type != types[i] || event.Repository != "broken" {
+			t.Errorf("event %d: got %s of %q, want %s of broken", i, event.Type, event.Repository, types[i])
+		}
+	}
+	if r.events[2].Error == "" {
+		t.Error("the repo-done event has no error")
+	}
+}
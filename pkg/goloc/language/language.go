@@ -1,10 +1,94 @@
 package language
 
+// StringLiteral describes how a string literal is opened and closed.
+// Escape is the character that protects the next one (\ in C, or the
+// closing quote itself when quotes are doubled, as in SQL). MultiLine
+// strings keep going past the end of the line (raw, backtick and
+// triple-quoted strings).
+type StringLiteral struct {
+	Start     string
+	End       string
+	Escape    string
+	MultiLine bool
+}
+
+// Heuristic helps to choose between several languages claiming the same
+// extension. Patterns are regular expressions matched against the start
+// of the file, Siblings are extensions of files found in the same
+// directory. Extensions restricts the rule to some of the extensions of
+// the language.
+type Heuristic struct {
+	Extensions []string
+	Patterns   []string
+	Siblings   []string
+}
+
+// ColumnRules describe fixed-format sources, where the position of a
+// character matters. They apply to the raw line, before whitespace is
+// trimmed: a line is a comment when one of Comments is found at its
+// column, otherwise only the columns from CodeStart to CodeEnd are kept,
+// which leaves sequence numbers out. Columns start at 1, and 0 means no
+// limit. A line matching one of the FreeFormat patterns switches the rest
+// of the file to free format, where columns are ignored, until a line
+// matches one of the FixedFormat patterns.
+type ColumnRules struct {
+	CodeStart   int
+	CodeEnd     int
+	Comments    []ColumnComment
+	FreeFormat  []string
+	FixedFormat []string
+}
+
+type ColumnComment struct {
+	Column    int
+	Indicator string
+}
+
+// Section is a part of a file written in another language, such as a
+// <script> block in an HTML page. Open and Close are regular expressions;
+// a "lang" group in Open selects the language of the section through
+// Languages, and Language is used otherwise. Tags belong to the enclosing
+// language unless the section is Inclusive, like <?php ... ?> blocks.
+// Nested sections count their opening tags, so that an inner closing tag
+// does not end them.
+type Section struct {
+	Open      string
+	Close     string
+	Language  string
+	Languages map[string]string
+	Inclusive bool
+	Nested    bool
+}
+
+// Nested is the optional third token of a MultiLineComments pair whose
+// comments nest, as /+ +/ in D: each opening token found inside such a
+// comment needs its own closing token.
+const Nested = "nested"
 
+// MultiLineComments are pairs of opening and closing tokens, followed by
+// Nested for the comments that nest. Filenames are matched against the
+// base name of a file, either exactly (Makefile) or as a pattern
+// (Dockerfile.*). Shebangs are interpreter
+// names, matched against the #! line of files without an extension.
+// Lines outside of Sections are counted as the Host language if it is
+// set, as for the HTML of a PHP page. Notebook files are Jupyter
+// notebooks, whose cells are counted in the language of their kernel.
+// Tests are path patterns, with the syntax of the path exclusions, that
+// match the test files of the language.
 type LanguageInfo struct {
 	LineComments      []string
 	MultiLineComments [][]string
+	Columns           ColumnRules
+	Sections          []Section
+	Host              string
+	Notebook          bool
 	Extensions        []string
+	Tests             []string
+	Filenames         []string
+	Shebangs          []string
+	Strings           []StringLiteral
+	Heredocs          []string
+	Heuristics        []Heuristic
 }
 
 type Languages map[string]LanguageInfo
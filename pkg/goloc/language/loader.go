@@ -0,0 +1,221 @@
+package language
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/filesystem"
+)
+
+const OriginBuiltin = "builtin"
+
+// LoadFile reads a JSON file of language definitions and merges it into
+// base. An entry named after an existing language only replaces the
+// fields it sets, any other entry adds a language. The merged set is
+// validated, and origins tells where each language comes from.
+func LoadFile(path string, base Languages) (Languages, map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var definitions map[string]json.RawMessage
+	if err := json.Unmarshal(data, &definitions); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse languages file %s: %v", path, err)
+	}
+
+	merged := Languages{}
+	origins := map[string]string{}
+	for name, languageInfo := range base {
+		merged[name] = languageInfo
+		origins[name] = OriginBuiltin
+	}
+
+	for name, definition := range definitions {
+		languageInfo := LanguageInfo{}
+		origin := "added by " + path
+		if builtin, ok := merged[name]; ok {
+			languageInfo = builtin.Clone()
+			origin = "overridden by " + path
+		}
+
+		if err := json.Unmarshal(definition, &languageInfo); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse language %s in %s: %v", name, path, err)
+		}
+		if err := languageInfo.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("invalid language %s in %s: %v", name, path, err)
+		}
+
+		merged[name] = languageInfo
+		origins[name] = origin
+	}
+
+	if err := merged.CheckClaims(); err != nil {
+		return nil, nil, fmt.Errorf("invalid languages file %s: %v", path, err)
+	}
+
+	return merged, origins, nil
+}
+
+// CheckClaims checks that no file name or interpreter is claimed by two
+// languages, since only extensions can be disambiguated by heuristics.
+func (languages Languages) CheckClaims() error {
+	filenames := map[string]string{}
+	shebangs := map[string]string{}
+	for _, name := range languages.Names() {
+		for _, filename := range languages[name].Filenames {
+			if other, ok := filenames[filename]; ok {
+				return fmt.Errorf("file name %s is claimed by both %s and %s", filename, other, name)
+			}
+			filenames[filename] = name
+		}
+		for _, shebang := range languages[name].Shebangs {
+			if other, ok := shebangs[shebang]; ok {
+				return fmt.Errorf("interpreter %s is claimed by both %s and %s", shebang, other, name)
+			}
+			shebangs[shebang] = name
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that a definition can be used by the analyzer and the
+// scanner.
+func (li LanguageInfo) Validate() error {
+	if len(li.Extensions) == 0 && len(li.Filenames) == 0 && len(li.Shebangs) == 0 {
+		return fmt.Errorf("no extension, file name or shebang")
+	}
+
+	for _, token := range li.LineComments {
+		if strings.TrimSpace(token) == "" {
+			return fmt.Errorf("empty line comment token")
+		}
+	}
+
+	for _, tokens := range li.MultiLineComments {
+		if len(tokens) < 2 || tokens[0] == "" || tokens[1] == "" {
+			return fmt.Errorf("multi line comments need an opening and a closing token: %q", tokens)
+		}
+		if len(tokens) > 3 || (len(tokens) == 3 && tokens[2] != Nested) {
+			return fmt.Errorf("multi line comments can only be followed by %q: %q", Nested, tokens)
+		}
+	}
+
+	for _, str := range li.Strings {
+		if str.Start == "" || str.End == "" {
+			return fmt.Errorf("strings need a start and an end token")
+		}
+	}
+
+	if li.Columns.CodeStart < 0 || li.Columns.CodeEnd < 0 ||
+		(li.Columns.CodeEnd > 0 && li.Columns.CodeEnd < li.Columns.CodeStart) {
+		return fmt.Errorf("invalid code columns %d-%d", li.Columns.CodeStart, li.Columns.CodeEnd)
+	}
+	for _, comment := range li.Columns.Comments {
+		if comment.Column < 1 || comment.Indicator == "" {
+			return fmt.Errorf("column comments need a column and an indicator")
+		}
+	}
+	for _, pattern := range append(cloneStrings(li.Columns.FreeFormat), li.Columns.FixedFormat...) {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid format pattern %q: %v", pattern, err)
+		}
+	}
+
+	for _, section := range li.Sections {
+		if section.Language == "" {
+			return fmt.Errorf("sections need a language")
+		}
+		for _, pattern := range []string{section.Open, section.Close} {
+			if pattern == "" {
+				return fmt.Errorf("sections need an opening and a closing pattern")
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("invalid section pattern %q: %v", pattern, err)
+			}
+			if re.MatchString("") {
+				return fmt.Errorf("section pattern %q matches an empty string", pattern)
+			}
+		}
+	}
+
+	if _, err := filesystem.NewMatcher("", li.Tests); err != nil {
+		return fmt.Errorf("invalid test pattern: %v", err)
+	}
+
+	for _, heuristic := range li.Heuristics {
+		for _, pattern := range heuristic.Patterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("invalid heuristic pattern %q: %v", pattern, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Clone returns a deep copy, so that decoding into it leaves the original
+// definition untouched.
+func (li LanguageInfo) Clone() LanguageInfo {
+	clone := li
+	clone.LineComments = cloneStrings(li.LineComments)
+	clone.Extensions = cloneStrings(li.Extensions)
+	clone.Tests = cloneStrings(li.Tests)
+	clone.Filenames = cloneStrings(li.Filenames)
+	clone.Shebangs = cloneStrings(li.Shebangs)
+	clone.Heredocs = cloneStrings(li.Heredocs)
+	clone.Strings = append([]StringLiteral(nil), li.Strings...)
+	clone.Columns.Comments = append([]ColumnComment(nil), li.Columns.Comments...)
+	clone.Columns.FreeFormat = cloneStrings(li.Columns.FreeFormat)
+	clone.Columns.FixedFormat = cloneStrings(li.Columns.FixedFormat)
+
+	clone.Sections = nil
+	for _, section := range li.Sections {
+		languages := map[string]string{}
+		for key, value := range section.Languages {
+			languages[key] = value
+		}
+		section.Languages = languages
+		clone.Sections = append(clone.Sections, section)
+	}
+
+	clone.MultiLineComments = nil
+	for _, tokens := range li.MultiLineComments {
+		clone.MultiLineComments = append(clone.MultiLineComments, cloneStrings(tokens))
+	}
+
+	clone.Heuristics = nil
+	for _, heuristic := range li.Heuristics {
+		clone.Heuristics = append(clone.Heuristics, Heuristic{
+			Extensions: cloneStrings(heuristic.Extensions),
+			Patterns:   cloneStrings(heuristic.Patterns),
+			Siblings:   cloneStrings(heuristic.Siblings),
+		})
+	}
+
+	return clone
+}
+
+// Names returns the language names in alphabetical order.
+func (l Languages) Names() []string {
+	names := make([]string, 0, len(l))
+	for name := range l {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func cloneStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append([]string{}, values...)
+}
@@ -0,0 +1,132 @@
+package language
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var baseLanguages = Languages{
+	"Python": {
+		LineComments:      []string{"#"},
+		MultiLineComments: [][]string{{"\"\"\"", "\"\"\""}},
+		Extensions:        []string{".py"},
+		Shebangs:          []string{"python"},
+	},
+	"Terraform": {
+		LineComments: []string{"#"},
+		Extensions:   []string{".tf"},
+	},
+}
+
+// writeLanguages writes a languages file to a temporary directory.
+func writeLanguages(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "languages.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	path := writeLanguages(t, `{
+		"Terraform": {"Extensions": [".tf", ".tfvars"], "LineComments": ["#", "//"], "MultiLineComments": [["/*", "*/"]]},
+		"Python": {"Extensions": [".py", ".pyi"]},
+		"Jsonnet": {"Extensions": [".jsonnet"], "LineComments": ["//"], "MultiLineComments": [["/*", "*/", "nested"]]}
+	}`)
+
+	merged, origins, err := LoadFile(path, baseLanguages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Join(merged.Names(), " "); got != "Jsonnet Python Terraform" {
+		t.Errorf("got languages %s", got)
+	}
+	wantOrigins := map[string]string{
+		"Jsonnet":   "added by " + path,
+		"Python":    "overridden by " + path,
+		"Terraform": "overridden by " + path,
+	}
+	for name, want := range wantOrigins {
+		if origins[name] != want {
+			t.Errorf("%s: got origin %q, want %q", name, origins[name], want)
+		}
+	}
+
+	// An override only replaces the fields it sets.
+	python := merged["Python"]
+	if strings.Join(python.Extensions, " ") != ".py .pyi" || strings.Join(python.LineComments, " ") != "#" ||
+		len(python.MultiLineComments) != 1 || strings.Join(python.Shebangs, " ") != "python" {
+		t.Errorf("got Python %+v", python)
+	}
+	terraform := merged["Terraform"]
+	if strings.Join(terraform.LineComments, " ") != "# //" || len(terraform.MultiLineComments) != 1 {
+		t.Errorf("got Terraform %+v", terraform)
+	}
+	if jsonnet := merged["Jsonnet"]; len(jsonnet.MultiLineComments) != 1 || jsonnet.MultiLineComments[0][2] != Nested {
+		t.Errorf("got Jsonnet %+v", jsonnet)
+	}
+
+	// The base set is left untouched.
+	if strings.Join(baseLanguages["Python"].Extensions, " ") != ".py" || len(baseLanguages["Terraform"].LineComments) != 1 {
+		t.Errorf("the base languages were changed: %+v", baseLanguages)
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "syntax", content: `{"Python": `, want: "failed to parse languages file"},
+		{name: "field type", content: `{"Python": {"Extensions": ".py"}}`, want: "failed to parse language Python"},
+		{name: "no extension", content: `{"Empty": {"LineComments": ["#"]}}`, want: "no extension, file name or shebang"},
+		{name: "empty comment", content: `{"Python": {"LineComments": [" "]}}`, want: "empty line comment token"},
+		{name: "unclosed comment", content: `{"Python": {"MultiLineComments": [["/*"]]}}`, want: "opening and a closing token"},
+		{name: "unknown comment flag", content: `{"Python": {"MultiLineComments": [["/*", "*/", "deep"]]}}`, want: "can only be followed by"},
+		{name: "string", content: `{"Python": {"Strings": [{"Start": "\""}]}}`, want: "strings need a start and an end token"},
+		{name: "test pattern", content: `{"Python": {"Tests": ["["]}}`, want: "invalid test pattern"},
+		{name: "heuristic", content: `{"Python": {"Heuristics": [{"Patterns": ["("]}]}}`, want: "invalid heuristic pattern"},
+		{name: "section", content: `{"Page": {"Extensions": [".page"], "Sections": [{"Open": "<a>", "Close": "x*", "Language": "Python"}]}}`, want: "matches an empty string"},
+		{name: "claimed interpreter", content: `{"Jython": {"Shebangs": ["python"]}}`, want: "interpreter python is claimed by both Jython and Python"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := LoadFile(writeLanguages(t, test.content), baseLanguages)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("got %v, want an error containing %q", err, test.want)
+			}
+		})
+	}
+
+	if _, _, err := LoadFile(filepath.Join(t.TempDir(), "missing.json"), baseLanguages); !os.IsNotExist(err) {
+		t.Errorf("got %v, want a missing file error", err)
+	}
+}
+
+func TestClone(t *testing.T) {
+	original := LanguageInfo{
+		Extensions:        []string{".x"},
+		MultiLineComments: [][]string{{"/*", "*/"}},
+		Sections:          []Section{{Open: "<a>", Close: "</a>", Language: "X", Languages: map[string]string{"x": "X"}}},
+		Heuristics:        []Heuristic{{Patterns: []string{"x"}}},
+	}
+
+	clone := original.Clone()
+	clone.Extensions[0] = ".y"
+	clone.MultiLineComments[0][0] = "{-"
+	clone.Sections[0].Languages["x"] = "Y"
+	clone.Heuristics[0].Patterns[0] = "y"
+
+	if original.Extensions[0] != ".x" || original.MultiLineComments[0][0] != "/*" ||
+		original.Sections[0].Languages["x"] != "X" || original.Heuristics[0].Patterns[0] != "x" {
+		t.Errorf("the clone shares its values with the original: %+v", original)
+	}
+}
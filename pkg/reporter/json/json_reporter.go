@@ -22,6 +22,7 @@ type languageResult struct {
 	BlankLines int
 	Comments   int
 	CodeLines  int
+	Test       *totals `json:",omitempty"`
 }
 
 type fileResult struct {
@@ -30,6 +31,31 @@ type fileResult struct {
 	BlankLines int
 	Comments   int
 	CodeLines  int
+	Test       bool `json:",omitempty"`
+}
+
+type totals struct {
+	Files      int `json:",omitempty"`
+	Lines      int
+	BlankLines int
+	Comments   int
+	CodeLines  int
+}
+
+type skippedFile struct {
+	File   string
+	Reason string
+}
+
+type fileError struct {
+	File  string
+	Error string
+}
+
+type profileExclusion struct {
+	Profile       string
+	Files         int
+	PhysicalLines int
 }
 
 type report struct {
@@ -39,6 +65,11 @@ type report struct {
 	TotalComments   int
 	TotalCodeLines  int
 	Results         interface{}
+	Production      totals
+	Test            totals
+	SkippedFiles    []skippedFile      `json:",omitempty"`
+	Errors          []fileError        `json:",omitempty"`
+	Excluded        []profileExclusion `json:",omitempty"`
 }
 
 func (j JsonReporter) GenerateReportByLanguage(summary *sorter.SortedSummary) error {
@@ -59,9 +90,15 @@ func (j JsonReporter) GenerateReportByLanguage(summary *sorter.SortedSummary) er
 			BlankLines: r.BlankLines,
 			Comments:   r.Comments,
 			CodeLines:  r.CodeLines,
+			Test:       getLanguageTest(summary, r),
 		})
 	}
 
+	jsonReport.Production, jsonReport.Test = getTotals(summary)
+	jsonReport.SkippedFiles = getSkippedFiles(summary)
+	jsonReport.Errors = getErrors(summary)
+	jsonReport.Excluded = getExcluded(summary)
+
 	return j.writeJson(jsonReport)
 }
 
@@ -81,12 +118,94 @@ func (j JsonReporter) GenerateReportByFile(summary *sorter.SortedSummary) error
 			BlankLines: r.BlankLines,
 			Comments:   r.Comments,
 			CodeLines:  r.CodeLines,
+			Test:       r.IsTest,
 		})
 	}
 
+	jsonReport.Production, jsonReport.Test = getTotals(summary)
+	jsonReport.SkippedFiles = getSkippedFiles(summary)
+	jsonReport.Errors = getErrors(summary)
+	jsonReport.Excluded = getExcluded(summary)
+
 	return j.writeJson(jsonReport)
 }
 
+func getLanguageTest(summary *sorter.SortedSummary, r sorter.Result) *totals {
+	files := summary.TestFilesByLanguage[r.Name]
+	if files == 0 {
+		return nil
+	}
+
+	return &totals{
+		Files:      files,
+		Lines:      r.TestLines,
+		BlankLines: r.TestBlankLines,
+		Comments:   r.TestComments,
+		CodeLines:  r.TestCodeLines,
+	}
+}
+
+// getTotals splits the totals of the report between production and test
+// code.
+func getTotals(summary *sorter.SortedSummary) (totals, totals) {
+	test := totals{
+		Files:      summary.TotalTestFiles,
+		Lines:      summary.TotalTestLines,
+		BlankLines: summary.TotalTestBlankLines,
+		Comments:   summary.TotalTestComments,
+		CodeLines:  summary.TotalTestCodeLines,
+	}
+	production := totals{
+		Files:      summary.TotalFiles - test.Files,
+		Lines:      summary.TotalLines - test.Lines,
+		BlankLines: summary.TotalBlankLines - test.BlankLines,
+		Comments:   summary.TotalComments - test.Comments,
+		CodeLines:  summary.TotalCodeLines - test.CodeLines,
+	}
+
+	return production, test
+}
+
+func getSkippedFiles(summary *sorter.SortedSummary) []skippedFile {
+	var skippedFiles []skippedFile
+
+	for _, s := range summary.SkippedFiles {
+		skippedFiles = append(skippedFiles, skippedFile{
+			File:   s.FilePath,
+			Reason: s.Reason,
+		})
+	}
+
+	return skippedFiles
+}
+
+func getErrors(summary *sorter.SortedSummary) []fileError {
+	var errors []fileError
+
+	for _, e := range summary.Errors {
+		errors = append(errors, fileError{
+			File:  e.FilePath,
+			Error: e.Err.Error(),
+		})
+	}
+
+	return errors
+}
+
+func getExcluded(summary *sorter.SortedSummary) []profileExclusion {
+	var excluded []profileExclusion
+
+	for _, e := range summary.Excluded {
+		excluded = append(excluded, profileExclusion{
+			Profile:       e.Profile,
+			Files:         e.Files,
+			PhysicalLines: e.PhysicalLines,
+		})
+	}
+
+	return excluded
+}
+
 func (j JsonReporter) writeJson(jsonReport *report) error {
 	loggers := utils.NewLogger()
 	file, err := json.MarshalIndent(jsonReport, "", "  ")
@@ -20,6 +20,7 @@ func (p PromptReporter) GenerateReportByLanguage(summary *sorter.SortedSummary)
 		"Blank lines",
 		"Comments",
 		"Code lines",
+		"Test code lines",
 	})
 	table.SetBorder(false)
 	table.SetAutoFormatHeaders(false)
@@ -32,6 +33,7 @@ func (p PromptReporter) GenerateReportByLanguage(summary *sorter.SortedSummary)
 			strconv.Itoa(file.BlankLines),
 			strconv.Itoa(file.Comments),
 			strconv.Itoa(file.CodeLines),
+			strconv.Itoa(file.TestCodeLines),
 		})
 	}
 
@@ -42,6 +44,7 @@ func (p PromptReporter) GenerateReportByLanguage(summary *sorter.SortedSummary)
 		strconv.Itoa(summary.TotalBlankLines),
 		strconv.Itoa(summary.TotalComments),
 		strconv.Itoa(summary.TotalCodeLines),
+		strconv.Itoa(summary.TotalTestCodeLines),
 	})
 
 	table.Render()
@@ -57,17 +60,23 @@ func (p PromptReporter) GenerateReportByFile(summary *sorter.SortedSummary) erro
 		"Blank lines",
 		"Comments",
 		"Code lines",
+		"Test",
 	})
 	table.SetBorder(false)
 	table.SetAutoFormatHeaders(false)
 
 	for _, file := range summary.Results {
+		test := ""
+		if file.IsTest {
+			test = "yes"
+		}
 		table.Append([]string{
 			file.Name,
 			strconv.Itoa(file.Lines),
 			strconv.Itoa(file.BlankLines),
 			strconv.Itoa(file.Comments),
 			strconv.Itoa(file.CodeLines),
+			test,
 		})
 	}
 
@@ -77,6 +86,7 @@ func (p PromptReporter) GenerateReportByFile(summary *sorter.SortedSummary) erro
 		strconv.Itoa(summary.TotalBlankLines),
 		strconv.Itoa(summary.TotalComments),
 		strconv.Itoa(summary.TotalCodeLines),
+		strconv.Itoa(summary.TotalTestCodeLines) + " test",
 	})
 
 	table.Render()
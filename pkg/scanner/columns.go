@@ -0,0 +1,91 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// patterns caches the regular expressions of the language definitions,
+// compiled once for all files.
+var patterns sync.Map
+
+// applyColumns evaluates the column rules of the language on a raw line.
+// It reports whether the line is a comment, and otherwise returns the
+// part of the line holding the code.
+func (lx *lexer) applyColumns(line string) (string, bool) {
+	rules := lx.language.Columns
+	if rules.CodeStart == 0 && rules.CodeEnd == 0 && len(rules.Comments) == 0 {
+		return line, false
+	}
+
+	if lx.freeFormat {
+		if matchesFormat(rules.FixedFormat, line) {
+			lx.freeFormat = false
+		}
+		return line, false
+	}
+	if matchesFormat(rules.FreeFormat, line) {
+		lx.freeFormat = true
+		return line, false
+	}
+
+	if lx.inBlockComment() || lx.str != nil || lx.heredocEnd != "" {
+		return columns(line, rules.CodeStart, rules.CodeEnd), false
+	}
+
+	for _, comment := range rules.Comments {
+		if strings.HasPrefix(columns(line, comment.Column, 0), comment.Indicator) {
+			return "", true
+		}
+	}
+
+	return columns(line, rules.CodeStart, rules.CodeEnd), false
+}
+
+// columns returns the characters of line from column start to column end,
+// both included and counted from 1. 0 means no limit.
+func columns(line string, start, end int) string {
+	if start <= 1 && end == 0 {
+		return line
+	}
+
+	runes := []rune(line)
+	if end > 0 && end < len(runes) {
+		runes = runes[:end]
+	}
+	if start > 1 {
+		if start > len(runes) {
+			return ""
+		}
+		runes = runes[start-1:]
+	}
+
+	return string(runes)
+}
+
+func matchesFormat(formats []string, line string) bool {
+	for _, format := range formats {
+		if re := compilePattern(format); re != nil && re.MatchString(line) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compilePattern returns the compiled pattern, or nil if it is invalid.
+// Definitions are validated when loaded, so this only guards the scan.
+func compilePattern(pattern string) *regexp.Regexp {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil
+	}
+	patterns.Store(pattern, re)
+
+	return re
+}
@@ -0,0 +1,142 @@
+package scanner
+
+import (
+	"context"
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/colussim/GoLC/assets"
+	"github.com/colussim/GoLC/pkg/analyzer"
+	"github.com/colussim/GoLC/pkg/filesystem"
+)
+
+// Files whose extension is claimed by several languages are counted with
+// the comment rules of the language chosen by the heuristics.
+func TestScanAmbiguousExtensions(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		src      string
+		siblings []string
+		language string
+		want     lineCounts
+	}{
+		{
+			name:     "C++ header by its keywords",
+			file:     "a.h",
+			src:      "namespace app {\n// a class\nclass A {};\n}\n",
+			language: "C++ Header",
+			want:     lineCounts{code: 3, comments: 1},
+		},
+		{
+			name:     "C++ header by its siblings",
+			file:     "b.h",
+			src:      "int f(void);\n/* f */\n",
+			siblings: []string{"b.cpp"},
+			language: "C++ Header",
+			want:     lineCounts{code: 1, comments: 1},
+		},
+		{
+			name:     "Objective-C header",
+			file:     "c.h",
+			src:      "#import <Foundation/Foundation.h>\n// a class\n@interface C : NSObject\n@end\n",
+			language: "Objective-C",
+			want:     lineCounts{code: 3, comments: 1},
+		},
+		{
+			name:     "C header without evidence",
+			file:     "d.h",
+			src:      "int d(void);\n// d\n",
+			language: "C Header",
+			want:     lineCounts{code: 1, comments: 1},
+		},
+		{
+			name:     "MATLAB function",
+			file:     "e.m",
+			src:      "function y = e(x)\n% doubles x\ny = 2 * x;\nend\n",
+			language: "MATLAB",
+			want:     lineCounts{code: 3, comments: 1},
+		},
+		{
+			name:     "Objective-C implementation",
+			file:     "f.m",
+			src:      "#import \"f.h\"\n// f\n@implementation F\n@end\n",
+			language: "Objective-C",
+			want:     lineCounts{code: 3, comments: 1},
+		},
+		{
+			name:     "Perl script",
+			file:     "g.pl",
+			src:      "use strict;\n# g\nmy $g = 1;\n",
+			language: "Perl",
+			want:     lineCounts{code: 2, comments: 1},
+		},
+		{
+			name:     "Prolog program",
+			file:     "h.pl",
+			src:      ":- module(h, []).\n% h\nh(X) :- g(X).\n",
+			language: "Prolog",
+			want:     lineCounts{code: 2, comments: 1},
+		},
+		{
+			name:     "TeX class",
+			file:     "i.cls",
+			src:      "\\NeedsTeXFormat{LaTeX2e}\n% i\n\\ProvidesClass{i}\n",
+			language: "TeX",
+			want:     lineCounts{code: 2, comments: 1},
+		},
+		{
+			name:     "VBA class",
+			file:     "j.cls",
+			src:      "VERSION 1.0 CLASS\n' j\nAttribute VB_Name = \"J\"\n",
+			language: "VBA",
+			want:     lineCounts{code: 2, comments: 1},
+		},
+		{
+			name:     "Apex class without evidence",
+			file:     "k.cls",
+			src:      "public class K {\n// k\n}\n",
+			language: "Apex",
+			want:     lineCounts{code: 2, comments: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tree := fstest.MapFS{test.file: {Data: []byte(test.src)}}
+			for _, sibling := range test.siblings {
+				tree[sibling] = &fstest.MapFile{}
+			}
+			source := &filesystem.Source{Root: "src", FS: tree}
+
+			a, err := analyzer.NewAnalyzer("src", nil, nil, nil, assets.Languages, analyzer.ContentFilter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			a.Source = source
+			files, err := a.MatchingFiles(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var file *analyzer.FileMetadata
+			for i := range files {
+				if files[i].FilePath == path.Join("src", test.file) {
+					file = &files[i]
+				}
+			}
+			if file == nil || file.Language != test.language {
+				t.Fatalf("got %+v, want %s", file, test.language)
+			}
+
+			sc := NewScanner(assets.Languages, 1)
+			sc.Source = source
+			result, err := sc.scanFile(*file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkCounts(t, result, test.want)
+		})
+	}
+}
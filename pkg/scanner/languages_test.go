@@ -0,0 +1,53 @@
+package scanner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/colussim/GoLC/assets"
+)
+
+// languageSample is an entry of testdata/languages/expected.json, the
+// counts of the sample of a language.
+type languageSample struct {
+	File     string `json:"file"`
+	Code     int    `json:"code"`
+	Comments int    `json:"comments"`
+	Blank    int    `json:"blank"`
+}
+
+// TestLanguageSamples scans the sample of each language of
+// assets.Languages, so that every new language comes with one.
+func TestLanguageSamples(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "languages", "expected.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var samples map[string]languageSample
+	if err := json.Unmarshal(data, &samples); err != nil {
+		t.Fatal(err)
+	}
+
+	for name := range samples {
+		if _, ok := assets.Languages[name]; !ok {
+			t.Errorf("sample of unknown language %s", name)
+		}
+	}
+
+	for _, name := range assets.Languages.Names() {
+		t.Run(name, func(t *testing.T) {
+			sample, ok := samples[name]
+			if !ok {
+				t.Fatalf("no sample in testdata/languages/expected.json")
+			}
+
+			src, err := os.ReadFile(filepath.Join("testdata", "languages", sample.File))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkCounts(t, scanSource(t, name, sample.File, src), lineCounts{code: sample.Code, comments: sample.Comments, blank: sample.Blank})
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package scanner
+
+import (
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/goloc/language"
+)
+
+type lineKind int
+
+const (
+	blankLine lineKind = iota
+	commentLine
+	codeLine
+)
+
+// lexer keeps the comment and string state of a file between two lines.
+// depth counts the open block comments when they nest, and freeFormat
+// tells that the column rules are switched off.
+type lexer struct {
+	language   language.LanguageInfo
+	blockOpen  string
+	blockClose string
+	nested     bool
+	depth      int
+	str        *language.StringLiteral
+	heredocEnd string
+	freeFormat bool
+}
+
+func newLexer(languageInfo language.LanguageInfo) *lexer {
+	return &lexer{
+		language: languageInfo,
+	}
+}
+
+func (lx *lexer) inBlockComment() bool {
+	return lx.blockClose != ""
+}
+
+// classify walks the line token by token. A line is code as soon as one
+// character outside of a comment is found, a comment if it only holds
+// comments, and blank otherwise. Comment tokens inside string literals
+// are ignored.
+func (lx *lexer) classify(line string) lineKind {
+	hasCode := false
+	hasComment := false
+
+	if lx.heredocEnd != "" {
+		if lx.endsHeredoc(line) {
+			lx.heredocEnd = ""
+		}
+		return codeLine
+	}
+
+	for i := 0; i < len(line); {
+		if lx.inBlockComment() {
+			hasComment = true
+			i = lx.skipBlockComment(line, i)
+			continue
+		}
+
+		if lx.str != nil {
+			hasCode = true
+			i = lx.skipString(line, i)
+			continue
+		}
+
+		if isSpace(line[i]) {
+			i++
+			continue
+		}
+
+		rest := line[i:]
+		lineComment := lx.matchLineComment(rest)
+		blockOpen, blockClose, nested := lx.matchBlockComment(rest)
+		str := lx.matchString(rest)
+
+		if lineComment != "" && len(lineComment) >= len(blockOpen) && (str == nil || len(lineComment) >= len(str.Start)) {
+			hasComment = true
+			break
+		}
+
+		// The closing token is only searched after the opening one, so
+		// symmetric delimiters such as """ do not close themselves. A
+		// token that also opens a string, as """ in Python, only opens a
+		// comment at the start of a statement, with no code before it.
+		if blockOpen != "" && (str == nil || len(blockOpen) > len(str.Start) ||
+			(len(blockOpen) == len(str.Start) && (blockOpen != str.Start || !hasCode))) {
+			hasComment = true
+			lx.blockOpen, lx.blockClose = blockOpen, blockClose
+			lx.nested = nested && blockOpen != blockClose
+			lx.depth = 1
+			i += len(blockOpen)
+			continue
+		}
+
+		hasCode = true
+
+		if str != nil {
+			lx.str = str
+			i += len(str.Start)
+			continue
+		}
+
+		if heredoc, end := lx.matchHeredoc(rest); heredoc != "" {
+			lx.heredocEnd = end
+			i += len(heredoc)
+			continue
+		}
+
+		i++
+	}
+
+	if lx.str != nil && !lx.str.MultiLine && !lx.continuesString(line) {
+		lx.str = nil
+	}
+
+	switch {
+	case hasCode:
+		return codeLine
+	case hasComment:
+		return commentLine
+	default:
+		return blankLine
+	}
+}
+
+// skipBlockComment consumes the current block comment from position i and
+// returns the position following its closing token, or the end of the
+// line if the comment continues on the next one.
+func (lx *lexer) skipBlockComment(line string, i int) int {
+	for i < len(line) {
+		rest := line[i:]
+		if lx.nested && strings.HasPrefix(rest, lx.blockOpen) {
+			lx.depth++
+			i += len(lx.blockOpen)
+			continue
+		}
+		if strings.HasPrefix(rest, lx.blockClose) {
+			lx.depth--
+			i += len(lx.blockClose)
+			if !lx.nested || lx.depth == 0 {
+				lx.blockOpen, lx.blockClose = "", ""
+				lx.depth = 0
+				return i
+			}
+			continue
+		}
+		i++
+	}
+
+	return len(line)
+}
+
+// skipString consumes the current string literal from position i and
+// returns the position following its closing token, or the end of the
+// line if the string continues on the next one.
+func (lx *lexer) skipString(line string, i int) int {
+	str := lx.str
+
+	for i < len(line) {
+		rest := line[i:]
+		if str.Escape != "" && strings.HasPrefix(rest, str.Escape) &&
+			(str.Escape != str.End || strings.HasPrefix(rest[len(str.Escape):], str.End)) {
+			i += len(str.Escape) + 1
+			continue
+		}
+		if strings.HasPrefix(rest, str.End) {
+			lx.str = nil
+			return i + len(str.End)
+		}
+		i++
+	}
+
+	return len(line)
+}
+
+// continuesString reports whether an unterminated single-line string is
+// continued on the next line by a trailing escape, as in C.
+func (lx *lexer) continuesString(line string) bool {
+	escape := lx.str.Escape
+	return escape != "" && escape != lx.str.End && strings.HasSuffix(line, escape)
+}
+
+func (lx *lexer) matchLineComment(text string) string {
+	match := ""
+
+	for _, lineComment := range lx.language.LineComments {
+		if lineComment != "" && len(lineComment) > len(match) && strings.HasPrefix(text, lineComment) {
+			match = lineComment
+		}
+	}
+
+	return match
+}
+
+// matchBlockComment returns the opening and closing tokens of the block
+// comment starting text, and whether it nests.
+func (lx *lexer) matchBlockComment(text string) (string, string, bool) {
+	open, close, nested := "", "", false
+
+	for _, multiLineComment := range lx.language.MultiLineComments {
+		if len(multiLineComment) < 2 || multiLineComment[0] == "" {
+			continue
+		}
+		if len(multiLineComment[0]) > len(open) && strings.HasPrefix(text, multiLineComment[0]) {
+			open, close = multiLineComment[0], multiLineComment[1]
+			nested = len(multiLineComment) > 2 && multiLineComment[2] == language.Nested
+		}
+	}
+
+	return open, close, nested
+}
+
+func (lx *lexer) matchString(text string) *language.StringLiteral {
+	var match *language.StringLiteral
+
+	for i := range lx.language.Strings {
+		str := &lx.language.Strings[i]
+		if str.Start == "" || str.End == "" {
+			continue
+		}
+		if (match == nil || len(str.Start) > len(match.Start)) && strings.HasPrefix(text, str.Start) {
+			match = str
+		}
+	}
+
+	return match
+}
+
+// matchHeredoc recognizes a heredoc opener such as <<EOF, <<-'EOF' or
+// <<~EOF and returns the consumed text and the terminator to wait for.
+func (lx *lexer) matchHeredoc(text string) (string, string) {
+	for _, heredoc := range lx.language.Heredocs {
+		if heredoc == "" || !strings.HasPrefix(text, heredoc) {
+			continue
+		}
+
+		i := len(heredoc)
+		if i < len(text) && (text[i] == '-' || text[i] == '~') {
+			i++
+		}
+
+		quote := byte(0)
+		if i < len(text) && (text[i] == '\'' || text[i] == '"') {
+			quote = text[i]
+			i++
+		}
+
+		start := i
+		for i < len(text) && isIdentifier(text[i], i == start) {
+			i++
+		}
+		if i == start {
+			continue
+		}
+		end := text[start:i]
+
+		if quote != 0 {
+			if i >= len(text) || text[i] != quote {
+				continue
+			}
+			i++
+		}
+
+		return text[:i], end
+	}
+
+	return "", ""
+}
+
+// endsHeredoc reports whether the line is the heredoc terminator, which
+// may be followed by punctuation as in PHP (EOF;).
+func (lx *lexer) endsHeredoc(line string) bool {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, lx.heredocEnd) {
+		return false
+	}
+	rest := line[len(lx.heredocEnd):]
+	return rest == "" || !isIdentifier(rest[0], false)
+}
+
+func isIdentifier(c byte, first bool) bool {
+	if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+		return true
+	}
+	return !first && c >= '0' && c <= '9'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == '\v'
+}
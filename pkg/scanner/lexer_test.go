@@ -0,0 +1,282 @@
+package scanner
+
+import (
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/colussim/GoLC/assets"
+	"github.com/colussim/GoLC/pkg/analyzer"
+	"github.com/colussim/GoLC/pkg/filesystem"
+)
+
+type lineCounts struct {
+	code, comments, blank int
+}
+
+// scanSource scans src as a file of the language, with the definitions of
+// assets.Languages.
+func scanSource(t *testing.T, lang, name string, src []byte) scanResult {
+	t.Helper()
+
+	sc := NewScanner(assets.Languages, 1)
+	sc.Source = &filesystem.Source{Root: "src", FS: fstest.MapFS{name: {Data: src}}}
+
+	result, err := sc.scanFile(analyzer.FileMetadata{FilePath: path.Join("src", name), Language: lang})
+	if err != nil {
+		t.Fatalf("scan %s: %v", name, err)
+	}
+
+	return result
+}
+
+func checkCounts(t *testing.T, result scanResult, want lineCounts) {
+	t.Helper()
+
+	got := lineCounts{code: result.CodeLines, comments: result.Comments, blank: result.BlankLines}
+	if got != want {
+		t.Errorf("got code=%d comments=%d blank=%d, want code=%d comments=%d blank=%d",
+			got.code, got.comments, got.blank, want.code, want.comments, want.blank)
+	}
+	if result.Lines != want.code+want.comments+want.blank {
+		t.Errorf("got %d lines, want %d", result.Lines, want.code+want.comments+want.blank)
+	}
+}
+
+func TestLexer(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		src      string
+		want     lineCounts
+	}{
+		{
+			name:     "code followed by a line comment",
+			language: "C",
+			src:      "int a = 1; // one\n// only a comment\n\nint b;\n",
+			want:     lineCounts{code: 2, comments: 1, blank: 1},
+		},
+		{
+			name:     "line comment token in a string",
+			language: "Golang",
+			src:      "s := \"http://example.com\"\nr := '/'\n",
+			want:     lineCounts{code: 2},
+		},
+		{
+			name:     "block comment token in a string",
+			language: "Java",
+			src:      "String s = \"/* not a comment\";\nint a;\n",
+			want:     lineCounts{code: 2},
+		},
+		{
+			name:     "escaped quote in a string",
+			language: "C",
+			src:      "char *s = \"a \\\" /* b\";\nint c;\n",
+			want:     lineCounts{code: 2},
+		},
+		{
+			name:     "comment token in a multi-line raw string",
+			language: "Golang",
+			src:      "s := `first\n// second\n/* third`\n",
+			want:     lineCounts{code: 3},
+		},
+		{
+			name:     "block comment opened after code",
+			language: "C",
+			src:      "int a; /* starts\nstill a comment\nends */\n",
+			want:     lineCounts{code: 1, comments: 2},
+		},
+		{
+			name:     "block comment closed before code",
+			language: "C",
+			src:      "/* starts\nends */ int a;\nint b;\n",
+			want:     lineCounts{code: 2, comments: 1},
+		},
+		{
+			name:     "block comment in the middle of a line",
+			language: "C",
+			src:      "int a /* size */ = 1;\n/* one */ /* two */\n",
+			want:     lineCounts{code: 1, comments: 1},
+		},
+		{
+			name:     "block comment opening tokens are not nested",
+			language: "C",
+			src:      "/* a /* b */ int c;\n",
+			want:     lineCounts{code: 1},
+		},
+		{
+			name:     "only the nesting delimiter nests",
+			language: "D",
+			src:      "/* a /* b */ code();\n/+ a /+ b +/ still +/\n",
+			want:     lineCounts{code: 1, comments: 1},
+		},
+		{
+			name:     "nested comment over several lines",
+			language: "D",
+			src:      "/+ a\n/+ b +/\nstill +/ code();\n",
+			want:     lineCounts{code: 1, comments: 2},
+		},
+		{
+			name:     "double quote in a char literal",
+			language: "Rust",
+			src:      "let q = '\"'; // quote\nlet e = '\\''; /* one */\n// only a comment\n",
+			want:     lineCounts{code: 2, comments: 1},
+		},
+		{
+			name:     "lifetimes are not char literals",
+			language: "Rust",
+			src:      "fn f<'a>(s: &'a str) -> &'a str { s } /* starts\nends */\n",
+			want:     lineCounts{code: 1, comments: 1},
+		},
+		{
+			name:     "line comment inside a block comment",
+			language: "C",
+			src:      "/* // */ int a;\n",
+			want:     lineCounts{code: 1},
+		},
+		{
+			name:     "longest comment token wins",
+			language: "Haskell",
+			src:      "{- block -}\n-- line\nmain = pure ()\n",
+			want:     lineCounts{code: 1, comments: 2},
+		},
+		{
+			name:     "SQL doubled quotes",
+			language: "SQL",
+			src:      "SELECT 'it''s -- here' FROM t; -- end\n-- only\n",
+			want:     lineCounts{code: 1, comments: 1},
+		},
+		{
+			name:     "shell heredoc",
+			language: "Shell",
+			src:      "cat <<EOF\n# not a comment\nEOF\n# a comment\n",
+			want:     lineCounts{code: 3, comments: 1},
+		},
+		{
+			name:     "whitespace only lines are blank",
+			language: "Python",
+			src:      "x = 1\n   \n\t\n",
+			want:     lineCounts{code: 1, blank: 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, ok := assets.Languages[test.language]; !ok {
+				t.Fatalf("unknown language %s", test.language)
+			}
+			checkCounts(t, scanSource(t, test.language, "file", []byte(test.src)), test.want)
+		})
+	}
+}
+
+// Symmetric delimiters open and close with the same token, which must not
+// close the comment it has just opened.
+func TestLexerSymmetricDelimiters(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		src      string
+		want     lineCounts
+	}{
+		{
+			name:     "docstring on one line",
+			language: "Python",
+			src:      "\"\"\"Module docstring.\"\"\"\nx = 1\n",
+			want:     lineCounts{code: 1, comments: 1},
+		},
+		{
+			name:     "single-quoted docstring on one line",
+			language: "Python",
+			src:      "'''Module docstring.'''\nx = 1\n",
+			want:     lineCounts{code: 1, comments: 1},
+		},
+		{
+			name:     "empty docstring",
+			language: "Python",
+			src:      "\"\"\"\"\"\"\nx = 1\n",
+			want:     lineCounts{code: 1, comments: 1},
+		},
+		{
+			name:     "docstring followed by code on the same line",
+			language: "Python",
+			src:      "\"\"\"doc\"\"\" ; x = 1\ny = 2\n",
+			want:     lineCounts{code: 2},
+		},
+		{
+			name:     "docstring over several lines",
+			language: "Python",
+			src:      "def f():\n    \"\"\"Start\n    middle\n    end\"\"\"\n    return 1\n",
+			want:     lineCounts{code: 2, comments: 3},
+		},
+		{
+			name:     "docstring opened and closed on its own lines",
+			language: "Python",
+			src:      "'''\ntext\n'''\nx = 1\n",
+			want:     lineCounts{code: 1, comments: 3},
+		},
+		{
+			name:     "other delimiter inside a docstring",
+			language: "Python",
+			src:      "\"\"\"it's ''' here\nstill \"\"\"\nx = 1\n",
+			want:     lineCounts{code: 1, comments: 2},
+		},
+		{
+			name:     "begin and end on the same line",
+			language: "Ruby",
+			src:      "=begin comment =end\nputs 1\n",
+			want:     lineCounts{code: 1, comments: 1},
+		},
+		{
+			name:     "begin and end on their own lines",
+			language: "Ruby",
+			src:      "=begin\ncomment\n=end\nputs 1\n",
+			want:     lineCounts{code: 1, comments: 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkCounts(t, scanSource(t, test.language, "file", []byte(test.src)), test.want)
+		})
+	}
+}
+
+// Triple quotes open a string after code, and a docstring comment at the
+// start of a statement.
+func TestLexerTripleQuotes(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want lineCounts
+	}{
+		{
+			name: "assignment",
+			src:  "x = \"\"\"\nSELECT 1\n\"\"\"\ny = 2\n",
+			want: lineCounts{code: 4},
+		},
+		{
+			name: "call argument",
+			src:  "f('''\nargument\n''')\n",
+			want: lineCounts{code: 3},
+		},
+		{
+			name: "comment token in a string",
+			src:  "x = \"\"\"# not a comment\n\"\"\"\n",
+			want: lineCounts{code: 2},
+		},
+		{
+			name: "docstring",
+			src:  "def f():\n    \"\"\"Start\n    end\"\"\"\n    return '''a'''\n",
+			want: lineCounts{code: 2, comments: 2},
+		},
+	}
+
+	for _, language := range []string{"Python", "Starlark"} {
+		for _, test := range tests {
+			t.Run(language+"/"+test.name, func(t *testing.T) {
+				checkCounts(t, scanSource(t, language, "file", []byte(test.src)), test.want)
+			})
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+type notebook struct {
+	Metadata struct {
+		Kernelspec struct {
+			Language string `json:"language"`
+		} `json:"kernelspec"`
+		LanguageInfo struct {
+			Name          string `json:"name"`
+			FileExtension string `json:"file_extension"`
+		} `json:"language_info"`
+	} `json:"metadata"`
+	Cells []notebookCell `json:"cells"`
+}
+
+type notebookCell struct {
+	CellType string          `json:"cell_type"`
+	Source   json.RawMessage `json:"source"`
+}
+
+// scanNotebook counts the code cells of a Jupyter notebook with the rules
+// of its kernel language, and the lines of its markdown cells as
+// comments. Lines are kept in Embedded under the kernel language.
+func (sc *Scanner) scanNotebook(result scanResult, r io.Reader) (scanResult, error) {
+	var nb notebook
+	if err := json.NewDecoder(r).Decode(&nb); err != nil {
+		return result, fmt.Errorf("failed to parse notebook %s: %v", result.Metadata.FilePath, err)
+	}
+
+	kernel := sc.kernelLanguage(nb)
+	if kernel == "" {
+		kernel = result.Metadata.Language
+	}
+	part := &LanguageResult{}
+
+	for _, cell := range nb.Cells {
+		if cell.CellType != "code" && cell.CellType != "markdown" {
+			continue
+		}
+
+		source, err := cellSource(cell.Source)
+		if err != nil {
+			return result, fmt.Errorf("failed to parse notebook %s: %v", result.Metadata.FilePath, err)
+		}
+
+		lexer := newLexer(sc.SupportedLanguages[kernel])
+		reader := newLineReader(strings.NewReader(source))
+		for {
+			line, err := reader.ReadLine()
+			if err != nil {
+				break
+			}
+			line = strings.TrimSpace(line)
+
+			kind := blankLine
+			switch {
+			case sc.isBlankLine(line):
+			case cell.CellType == "markdown":
+				kind = commentLine
+			default:
+				kind = lexer.classify(line)
+			}
+
+			switch kind {
+			case codeLine:
+				part.CodeLines++
+			case commentLine:
+				part.Comments++
+			default:
+				part.BlankLines++
+			}
+			part.Lines++
+		}
+	}
+
+	result.Lines = part.Lines
+	result.CodeLines = part.CodeLines
+	result.BlankLines = part.BlankLines
+	result.Comments = part.Comments
+	result.Embedded = map[string]*LanguageResult{kernel: part}
+
+	return result, nil
+}
+
+// kernelLanguage finds the language of the notebook by the file extension
+// of its kernel, then by the name of its language.
+func (sc *Scanner) kernelLanguage(nb notebook) string {
+	if extension := nb.Metadata.LanguageInfo.FileExtension; extension != "" {
+		var candidates []string
+		for name, languageInfo := range sc.SupportedLanguages {
+			if languageInfo.Notebook {
+				continue
+			}
+			for _, candidate := range languageInfo.Extensions {
+				if candidate == extension {
+					candidates = append(candidates, name)
+				}
+			}
+		}
+		if len(candidates) > 0 {
+			sort.Strings(candidates)
+			return candidates[0]
+		}
+	}
+
+	for _, kernel := range []string{nb.Metadata.LanguageInfo.Name, nb.Metadata.Kernelspec.Language} {
+		if kernel == "" {
+			continue
+		}
+		for name, languageInfo := range sc.SupportedLanguages {
+			if !languageInfo.Notebook && strings.EqualFold(name, kernel) {
+				return name
+			}
+		}
+	}
+
+	return ""
+}
+
+// cellSource joins the source of a cell, stored either as a string or as
+// a list of lines.
+func cellSource(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 {
+		return "", nil
+	}
+
+	var lines []string
+	if err := json.Unmarshal(raw, &lines); err == nil {
+		return strings.Join(lines, ""), nil
+	}
+
+	var source string
+	if err := json.Unmarshal(raw, &source); err != nil {
+		return "", err
+	}
+
+	return source, nil
+}
@@ -0,0 +1,158 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// lineReader splits a file into lines ended by LF, CRLF or a lone CR, the
+// last line being returned even without a line ending. A byte order mark
+// is dropped, and UTF-16 content is decoded to UTF-8.
+type lineReader struct {
+	reader  *bufio.Reader
+	pending []byte
+	err     error
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	reader := bufio.NewReader(r)
+
+	bom, _ := reader.Peek(3)
+	switch {
+	case bytes.HasPrefix(bom, bomUTF8):
+		reader.Discard(len(bomUTF8))
+	case bytes.HasPrefix(bom, bomUTF16LE):
+		reader.Discard(len(bomUTF16LE))
+		reader = bufio.NewReader(&utf16Reader{reader: reader, order: binary.LittleEndian})
+	case bytes.HasPrefix(bom, bomUTF16BE):
+		reader.Discard(len(bomUTF16BE))
+		reader = bufio.NewReader(&utf16Reader{reader: reader, order: binary.BigEndian})
+	}
+
+	return &lineReader{reader: reader}
+}
+
+// ReadLine returns the next line without its line ending, and io.EOF once
+// the whole file has been read.
+func (lr *lineReader) ReadLine() (string, error) {
+	var line []byte
+
+	for {
+		if len(lr.pending) == 0 {
+			if lr.err != nil && lr.err != bufio.ErrBufferFull {
+				if len(line) > 0 && lr.err == io.EOF {
+					return string(line), nil
+				}
+				return string(line), lr.err
+			}
+
+			chunk, err := lr.reader.ReadSlice('\n')
+			lr.pending = append(lr.pending[:0], chunk...)
+			lr.err = err
+			if len(lr.pending) == 0 {
+				continue
+			}
+		}
+
+		end := bytes.IndexAny(lr.pending, "\r\n")
+		if end < 0 {
+			line = append(line, lr.pending...)
+			lr.pending = lr.pending[:0]
+			continue
+		}
+
+		line = append(line, lr.pending[:end]...)
+		next := end + 1
+		if lr.pending[end] == '\r' {
+			if next < len(lr.pending) && lr.pending[next] == '\n' {
+				next++
+			} else if next == len(lr.pending) && lr.err == bufio.ErrBufferFull {
+				// The LF of a CRLF may start the next chunk.
+				if b, _ := lr.reader.Peek(1); len(b) == 1 && b[0] == '\n' {
+					lr.reader.Discard(1)
+				}
+			}
+		}
+		lr.pending = lr.pending[next:]
+
+		return string(line), nil
+	}
+}
+
+// utf16Reader decodes a UTF-16 stream to UTF-8. Unpaired surrogates and a
+// trailing odd byte are replaced by U+FFFD.
+type utf16Reader struct {
+	reader  io.Reader
+	order   binary.ByteOrder
+	decoded []byte
+	unit    [2]byte
+	pending rune
+	held    bool
+	err     error
+}
+
+func (ur *utf16Reader) Read(p []byte) (int, error) {
+	for len(ur.decoded) < len(p) && ur.err == nil {
+		ur.decodeRune()
+	}
+	if len(ur.decoded) == 0 {
+		return 0, ur.err
+	}
+
+	n := copy(p, ur.decoded)
+	ur.decoded = append(ur.decoded[:0], ur.decoded[n:]...)
+
+	return n, nil
+}
+
+func (ur *utf16Reader) decodeRune() {
+	r, ok := ur.readUnit()
+	if !ok {
+		return
+	}
+
+	if utf16.IsSurrogate(r) {
+		if r >= 0xDC00 {
+			r = utf8.RuneError
+		} else if low, ok := ur.readUnit(); !ok {
+			r = utf8.RuneError
+		} else if decoded := utf16.DecodeRune(r, low); decoded != utf8.RuneError {
+			r = decoded
+		} else {
+			// Not a low surrogate, it is decoded on its own next time.
+			ur.pending, ur.held = low, true
+			r = utf8.RuneError
+		}
+	}
+
+	ur.decoded = utf8.AppendRune(ur.decoded, r)
+}
+
+func (ur *utf16Reader) readUnit() (rune, bool) {
+	if ur.held {
+		ur.held = false
+		return ur.pending, true
+	}
+
+	_, err := io.ReadFull(ur.reader, ur.unit[:])
+	if err == io.ErrUnexpectedEOF {
+		ur.err = io.EOF
+		return utf8.RuneError, true
+	}
+	if err != nil {
+		ur.err = err
+		return 0, false
+	}
+
+	return rune(ur.order.Uint16(ur.unit[:])), true
+}
@@ -0,0 +1,74 @@
+package scanner
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// The files of testdata/encodings hold the same C source, whose counts
+// are the ones of cloc for the LF version: 5 code lines, 1 comment and 1
+// blank line.
+func TestLineReaderEncodings(t *testing.T) {
+	want := lineCounts{code: 5, comments: 1, blank: 1}
+
+	for _, name := range []string{
+		"lf.c",
+		"crlf.c",
+		"cr.c",
+		"no_final_newline.c",
+		"utf8_bom.c",
+		"utf16le_bom.c",
+		"utf16be_bom.c",
+	} {
+		t.Run(name, func(t *testing.T) {
+			src, err := os.ReadFile(filepath.Join("testdata", "encodings", name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkCounts(t, scanSource(t, "C", name, src), want)
+		})
+	}
+}
+
+func TestLineReaderLines(t *testing.T) {
+	want := readLines(t, "lf.c")
+
+	for _, name := range []string{"crlf.c", "cr.c", "no_final_newline.c", "utf8_bom.c", "utf16le_bom.c", "utf16be_bom.c"} {
+		t.Run(name, func(t *testing.T) {
+			got := readLines(t, name)
+			if len(got) != len(want) {
+				t.Fatalf("got %d lines %q, want %d", len(got), got, len(want))
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("line %d is %q, want %q", i+1, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func readLines(t *testing.T, name string) []string {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", "encodings", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var lines []string
+	reader := newLineReader(f)
+	for {
+		line, err := reader.ReadLine()
+		if err == io.EOF {
+			return lines
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, line)
+	}
+}
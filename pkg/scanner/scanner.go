@@ -1,18 +1,32 @@
 package scanner
 
 import (
-	"bufio"
+	"context"
 	"io"
+	"io/fs"
 	"os"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/colussim/GoLC/pkg/analyzer"
+	"github.com/colussim/GoLC/pkg/events"
+	"github.com/colussim/GoLC/pkg/filesystem"
 	"github.com/colussim/GoLC/pkg/goloc/language"
-	"github.com/schollz/progressbar/v3"
 )
 
 type Scanner struct {
 	SupportedLanguages language.Languages
+	Workers            int
+	Strict             bool
+	Errors             []analyzer.FileError
+	// Source serves the files to scan, they are read from disk if it is
+	// not set.
+	Source *filesystem.Source
+	// Observer receives a FileScanned event for each file, the default
+	// observer is used if it is not set.
+	Observer events.Observer
 }
 
 type scanResult struct {
@@ -21,45 +35,164 @@ type scanResult struct {
 	CodeLines  int
 	BlankLines int
 	Comments   int
+	Embedded   map[string]*LanguageResult
 }
 
-func NewScanner(languages language.Languages) *Scanner {
+func NewScanner(languages language.Languages, workers int) *Scanner {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
 	return &Scanner{
 		SupportedLanguages: languages,
+		Workers:            workers,
 	}
 }
 
-func (sc *Scanner) Scan(files []analyzer.FileMetadata) ([]scanResult, error) {
-	var results []scanResult
-	progress := sc.createProgressbar(len(files))
+// scanOutcome is the result of the scan of the index-th file, sent by the
+// workers.
+type scanOutcome struct {
+	index  int
+	file   analyzer.FileMetadata
+	result scanResult
+	err    error
+}
+
+// Scan counts the lines of the files and adds them to summary as they are
+// scanned, so that only the per-language totals are kept in memory. Unless
+// the scanner is Strict, files that cannot be read are recorded in Errors
+// and left out of the summary. The scan stops when ctx is done.
+func (sc *Scanner) Scan(ctx context.Context, files []analyzer.FileMetadata, summary *Summary) error {
+	sc.Errors = nil
+
+	var err error
+	if sc.Workers <= 1 || len(files) <= 1 {
+		err = sc.scanSequential(ctx, files, summary)
+	} else {
+		err = sc.scanParallel(ctx, files, summary)
+	}
+
+	sort.Slice(sc.Errors, func(i, j int) bool {
+		return sc.Errors[i].FilePath < sc.Errors[j].FilePath
+	})
+
+	return err
+}
 
-	for _, file := range files {
+func (sc *Scanner) scanSequential(ctx context.Context, files []analyzer.FileMetadata, summary *Summary) error {
+	for i, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		result, err := sc.scanFile(file)
+		sc.fileScanned(file, result, i+1, len(files))
 		if err != nil {
-			return results, err
+			if sc.Strict {
+				return err
+			}
+			sc.Errors = append(sc.Errors, analyzer.FileError{FilePath: file.FilePath, Err: err})
+			continue
+		}
+		summary.add(result)
+	}
+
+	return nil
+}
+
+// scanParallel spreads the files over a bounded pool of workers, whose
+// results are added to the summary by the calling goroutine in the order
+// of files, whatever the order the workers finish in. In strict
+// mode, the first error stops the dispatch of the remaining files, as does
+// the end of ctx.
+func (sc *Scanner) scanParallel(ctx context.Context, files []analyzer.FileMetadata, summary *Summary) error {
+	jobs := make(chan int)
+	outcomes := make(chan scanOutcome)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+
+	workers := sc.Workers
+	if workers > len(files) {
+		workers = len(files)
+	}
+
+	go func() {
+		defer close(jobs)
+		for i := range files {
+			select {
+			case jobs <- i:
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result, err := sc.scanFile(files[i])
+				outcomes <- scanOutcome{index: i, file: files[i], result: result, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	var firstErr error
+	scanned := 0
+	// Outcomes that arrive before the ones of the previous files wait in
+	// pending until next reaches them.
+	pending := make(map[int]scanOutcome)
+	next := 0
+	for outcome := range outcomes {
+		scanned++
+		sc.fileScanned(outcome.file, outcome.result, scanned, len(files))
+		if outcome.err != nil {
+			if !sc.Strict {
+				sc.Errors = append(sc.Errors, analyzer.FileError{FilePath: outcome.file.FilePath, Err: outcome.err})
+			} else if firstErr == nil {
+				firstErr = outcome.err
+				close(stop)
+			}
+		}
+
+		pending[outcome.index] = outcome
+		for {
+			ready, ok := pending[next]
+			if !ok {
+				break
+			}
+			if ready.err == nil && firstErr == nil {
+				summary.add(ready.result)
+			}
+			delete(pending, next)
+			next++
 		}
-		progress.Add(1)
-		results = append(results, result)
 	}
 
-	return results, nil
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+
+	return firstErr
 }
 
-func (sc *Scanner) createProgressbar(max int) *progressbar.ProgressBar {
-	return progressbar.NewOptions(
-		max,
-		progressbar.OptionSetDescription("Scanning files..."),
-		progressbar.OptionShowBytes(true),
-		progressbar.OptionShowCount(),
-		progressbar.OptionClearOnFinish(),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "=",
-			SaucerHead:    ">",
-			SaucerPadding: " ",
-			BarStart:      "[",
-			BarEnd:        "]",
-		}),
-	)
+// fileScanned sends the FileScanned event of the n-th file out of total.
+func (sc *Scanner) fileScanned(file analyzer.FileMetadata, result scanResult, n, total int) {
+	events.Notify(sc.Observer, events.Event{
+		Type:      events.FileScanned,
+		Path:      file.FilePath,
+		Files:     n,
+		Total:     total,
+		Lines:     result.Lines,
+		CodeLines: result.CodeLines,
+	})
 }
 
 // OLD Function
@@ -118,55 +251,52 @@ func (sc *Scanner) createProgressbar(max int) *progressbar.ProgressBar {
 
 func (sc *Scanner) scanFile(file analyzer.FileMetadata) (scanResult, error) {
 	result := scanResult{Metadata: file}
-	isInBlockComment := false
-	var closeBlockCommentToken string
+	lexer := newLexer(sc.SupportedLanguages[file.Language])
 
-	f, err := os.Open(file.FilePath)
+	f, err := sc.open(file.FilePath)
 	if err != nil {
 		return result, err
 	}
 	defer f.Close()
 
-	reader := bufio.NewReader(f)
+	if sc.SupportedLanguages[file.Language].Notebook {
+		return sc.scanNotebook(result, f)
+	}
+
+	reader := newLineReader(f)
+	if len(sc.SupportedLanguages[file.Language].Sections) > 0 {
+		return sc.scanSections(result, reader)
+	}
+
 	for {
-		line, err := reader.ReadString('\n')
+		line, err := reader.ReadLine()
 		if err != nil {
 			if err == io.EOF {
 				break
 			}
 			return result, err
 		}
-		line = strings.TrimSpace(line)
 
-		if isInBlockComment {
+		line, isComment := lexer.applyColumns(line)
+		if isComment {
 			result.Comments++
-			if sc.hasSecondMultiLineComment(line, closeBlockCommentToken) {
-				isInBlockComment = false
-			}
 			continue
 		}
+		line = strings.TrimSpace(line)
 
 		if sc.isBlankLine(line) {
 			result.BlankLines++
 			continue
 		}
 
-		if ok, secondCommentToken := sc.hasFirstMultiLineComment(file, line); ok {
-			isInBlockComment = true
-			closeBlockCommentToken = secondCommentToken
+		switch lexer.classify(line) {
+		case codeLine:
+			result.CodeLines++
+		case commentLine:
 			result.Comments++
-			if sc.hasSecondMultiLineComment(line, closeBlockCommentToken) {
-				isInBlockComment = false
-			}
-			continue
-		}
-
-		if sc.hasSingleLineComment(file, line) {
-			result.Comments++
-			continue
+		default:
+			result.BlankLines++
 		}
-
-		result.CodeLines++
 	}
 
 	result.Lines = result.CodeLines + result.BlankLines + result.Comments
@@ -174,33 +304,55 @@ func (sc *Scanner) scanFile(file analyzer.FileMetadata) (scanResult, error) {
 	return result, nil
 }
 
-func (sc *Scanner) hasFirstMultiLineComment(file analyzer.FileMetadata, line string) (bool, string) {
-	multiLineComments := sc.SupportedLanguages[file.Language].MultiLineComments
-
-	for _, multiLineComment := range multiLineComments {
-		firstCommentToken := multiLineComment[0]
-		if strings.HasPrefix(line, firstCommentToken) {
-			return true, multiLineComment[1]
-		}
+// open opens a file from disk, or from the source of the scanner when it
+// is set.
+func (sc *Scanner) open(path string) (fs.File, error) {
+	if sc.Source == nil {
+		return os.Open(path)
 	}
 
-	return false, ""
+	return sc.Source.Open(path)
 }
 
-func (sc *Scanner) hasSecondMultiLineComment(line, commentToken string) bool {
-	return strings.Contains(line, commentToken)
-}
+// scanSections counts the lines of a file mixing several languages, and
+// keeps the lines of each language in Embedded.
+func (sc *Scanner) scanSections(result scanResult, reader *lineReader) (scanResult, error) {
+	splitter := newSectionSplitter(sc.SupportedLanguages, result.Metadata.Language)
+	result.Embedded = make(map[string]*LanguageResult)
 
-func (sc *Scanner) hasSingleLineComment(file analyzer.FileMetadata, line string) bool {
-	lineComments := sc.SupportedLanguages[file.Language].LineComments
+	for {
+		line, err := reader.ReadLine()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return result, err
+		}
 
-	for _, lineComment := range lineComments {
-		if strings.HasPrefix(line, lineComment) {
-			return true
+		language, kind := splitter.classify(line)
+		part, ok := result.Embedded[language]
+		if !ok {
+			part = &LanguageResult{}
+			result.Embedded[language] = part
 		}
+
+		switch kind {
+		case codeLine:
+			part.CodeLines++
+			result.CodeLines++
+		case commentLine:
+			part.Comments++
+			result.Comments++
+		default:
+			part.BlankLines++
+			result.BlankLines++
+		}
+		part.Lines++
 	}
 
-	return false
+	result.Lines = result.CodeLines + result.BlankLines + result.Comments
+
+	return result, nil
 }
 
 func (sc *Scanner) isBlankLine(line string) bool {
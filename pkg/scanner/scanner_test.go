@@ -0,0 +1,101 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/colussim/GoLC/assets"
+	"github.com/colussim/GoLC/pkg/analyzer"
+)
+
+// syntheticTree writes n Go files of different sizes to a temporary
+// directory.
+func syntheticTree(tb testing.TB, n int) []analyzer.FileMetadata {
+	root := tb.TempDir()
+	files := make([]analyzer.FileMetadata, 0, n)
+
+	for i := 0; i < n; i++ {
+		var src strings.Builder
+		src.WriteString("package synthetic\n\n")
+		for j := 0; j < 10+i%50; j++ {
+			fmt.Fprintf(&src, "// f%d returns its index.\nfunc f%d() int { return %d } /* done */\n\n", j, j, j)
+		}
+
+		dir := filepath.Join(root, fmt.Sprintf("dir%d", i%10))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			tb.Fatal(err)
+		}
+		file := filepath.Join(dir, fmt.Sprintf("file%d.go", i))
+		if err := os.WriteFile(file, []byte(src.String()), 0o644); err != nil {
+			tb.Fatal(err)
+		}
+		files = append(files, analyzer.FileMetadata{FilePath: file, Extension: ".go", Language: "Golang"})
+	}
+
+	return files
+}
+
+func scanTree(tb testing.TB, files []analyzer.FileMetadata, workers int) *Summary {
+	sc := NewScanner(assets.Languages, workers)
+
+	summary := NewSummary(true)
+	if err := sc.Scan(context.Background(), files, summary); err != nil {
+		tb.Fatal(err)
+	}
+
+	return summary
+}
+
+func TestScanParallelKeepsFileOrder(t *testing.T) {
+	files := syntheticTree(t, 200)
+	summary := scanTree(t, files, 8)
+
+	if len(summary.Files) != len(files) {
+		t.Fatalf("got %d files, want %d", len(summary.Files), len(files))
+	}
+	for i, file := range files {
+		if summary.Files[i].Path != file.FilePath {
+			t.Fatalf("file %d is %s, want %s", i, summary.Files[i].Path, file.FilePath)
+		}
+	}
+}
+
+func TestScanParallelMatchesSequential(t *testing.T) {
+	files := syntheticTree(t, 500)
+	sequential := scanTree(t, files, 1)
+	parallel := scanTree(t, files, 8)
+
+	if parallel.TotalFiles != sequential.TotalFiles ||
+		parallel.TotalLines != sequential.TotalLines ||
+		parallel.TotalCodeLines != sequential.TotalCodeLines ||
+		parallel.TotalBlankLines != sequential.TotalBlankLines ||
+		parallel.TotalComments != sequential.TotalComments {
+		t.Fatalf("parallel totals %+v differ from sequential totals %+v", *parallel, *sequential)
+	}
+	for language, want := range sequential.Languages {
+		if got := parallel.Languages[language]; got == nil || *got != *want {
+			t.Errorf("%s: got %+v, want %+v", language, got, want)
+		}
+	}
+}
+
+func BenchmarkScan(b *testing.B) {
+	files := syntheticTree(b, 5000)
+	b.ResetTimer()
+
+	for _, workers := range []int{1, 8} {
+		name := "sequential"
+		if workers > 1 {
+			name = fmt.Sprintf("parallel-%d", workers)
+		}
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				scanTree(b, files, workers)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/goloc/language"
+)
+
+// sectionSplitter cuts the lines of a file mixing several languages into
+// fragments, each one classified by the lexer of its own language.
+type sectionSplitter struct {
+	languages language.Languages
+	host      string
+	hostLexer *lexer
+	sections  []language.Section
+	current   *openSection
+}
+
+type openSection struct {
+	language  string
+	lexer     *lexer
+	open      *regexp.Regexp
+	close     *regexp.Regexp
+	inclusive bool
+	nested    bool
+	depth     int
+	skip      int
+}
+
+type fragment struct {
+	language string
+	lexer    *lexer
+	text     string
+}
+
+func newSectionSplitter(languages language.Languages, name string) *sectionSplitter {
+	languageInfo := languages[name]
+	sections := languageInfo.Sections
+
+	host := name
+	if hostInfo, ok := languages[languageInfo.Host]; ok && languageInfo.Host != name {
+		host = languageInfo.Host
+		sections = append(append([]language.Section{}, sections...), hostInfo.Sections...)
+	}
+
+	return &sectionSplitter{
+		languages: languages,
+		host:      host,
+		hostLexer: newLexer(languages[host]),
+		sections:  sections,
+	}
+}
+
+// classify returns the language and the kind of a line. A line holding
+// code in several languages is counted once, for the first of them.
+func (ss *sectionSplitter) classify(line string) (string, lineKind) {
+	fragments := ss.split(line)
+	name, kind := fragments[0].language, blankLine
+
+	for _, f := range fragments {
+		switch f.lexer.classify(strings.TrimSpace(f.text)) {
+		case codeLine:
+			if kind != codeLine {
+				name, kind = f.language, codeLine
+			}
+		case commentLine:
+			if kind == blankLine {
+				name, kind = f.language, commentLine
+			}
+		}
+	}
+
+	return name, kind
+}
+
+func (ss *sectionSplitter) split(line string) []fragment {
+	var fragments []fragment
+	pos := 0
+
+	for {
+		if current := ss.current; current != nil {
+			start, end := current.findClose(line, pos)
+			if end < 0 {
+				return append(fragments, fragment{current.language, current.lexer, line[pos:]})
+			}
+
+			if current.inclusive {
+				fragments = append(fragments, fragment{current.language, current.lexer, line[pos:end]})
+			} else {
+				fragments = append(fragments,
+					fragment{current.language, current.lexer, line[pos:start]},
+					fragment{ss.host, ss.hostLexer, line[start:end]})
+			}
+			ss.current = nil
+			pos = end
+			continue
+		}
+
+		section, loc := ss.findOpen(line[pos:])
+		if section == nil {
+			return append(fragments, fragment{ss.host, ss.hostLexer, line[pos:]})
+		}
+
+		name := ss.sectionLanguage(section, line[pos:], loc)
+		start, end := pos+loc[0], pos+loc[1]
+		current := &openSection{
+			language:  name,
+			lexer:     newLexer(ss.languages[name]),
+			open:      compilePattern(section.Open),
+			close:     compilePattern(section.Close),
+			inclusive: section.Inclusive,
+			nested:    section.Nested,
+		}
+
+		if section.Inclusive {
+			fragments = append(fragments, fragment{ss.host, ss.hostLexer, line[pos:start]})
+			current.skip = end
+			pos = start
+		} else {
+			fragments = append(fragments, fragment{ss.host, ss.hostLexer, line[pos:end]})
+			pos = end
+		}
+		ss.current = current
+	}
+}
+
+// findOpen returns the section opened first in text, with the submatch
+// indexes of its opening tag.
+func (ss *sectionSplitter) findOpen(text string) (*language.Section, []int) {
+	var first *language.Section
+	var firstLoc []int
+
+	for i := range ss.sections {
+		re := compilePattern(ss.sections[i].Open)
+		if re == nil || compilePattern(ss.sections[i].Close) == nil {
+			continue
+		}
+		loc := re.FindStringSubmatchIndex(text)
+		if loc != nil && (firstLoc == nil || loc[0] < firstLoc[0]) {
+			first, firstLoc = &ss.sections[i], loc
+		}
+	}
+
+	return first, firstLoc
+}
+
+// sectionLanguage resolves the "lang" group of the opening tag. Sections
+// in a language that is not supported are counted as the host.
+func (ss *sectionSplitter) sectionLanguage(section *language.Section, text string, loc []int) string {
+	name := section.Language
+
+	if i := compilePattern(section.Open).SubexpIndex("lang"); i > 0 && loc[2*i] >= 0 {
+		value := strings.ToLower(text[loc[2*i]:loc[2*i+1]])
+		if mapped, ok := section.Languages[value]; ok {
+			name = mapped
+		}
+	}
+
+	if _, ok := ss.languages[name]; !ok {
+		return ss.host
+	}
+
+	return name
+}
+
+// findClose returns the position of the closing tag of the section in
+// line, starting from pos, or -1 if the section goes on.
+func (op *openSection) findClose(line string, pos int) (int, int) {
+	from := pos
+	if op.skip > from {
+		from = op.skip
+	}
+	op.skip = 0
+
+	for {
+		closeLoc := op.close.FindStringIndex(line[from:])
+
+		if op.nested {
+			openLoc := op.open.FindStringIndex(line[from:])
+			if openLoc != nil && (closeLoc == nil || openLoc[0] < closeLoc[0]) {
+				op.depth++
+				from += openLoc[1]
+				continue
+			}
+		}
+
+		if closeLoc == nil {
+			return -1, -1
+		}
+		if op.depth > 0 {
+			op.depth--
+			from += closeLoc[1]
+			continue
+		}
+
+		return from + closeLoc[0], from + closeLoc[1]
+	}
+}
@@ -1,5 +1,7 @@
 package scanner
 
+import "github.com/colussim/GoLC/pkg/analyzer"
+
 type LanguageResult struct {
 	Lines      int
 	CodeLines  int
@@ -13,6 +15,7 @@ type FileResult struct {
 	CodeLines  int
 	BlankLines int
 	Comments   int
+	IsTest     bool
 }
 
 type Summary struct {
@@ -24,44 +27,116 @@ type Summary struct {
 	TotalCodeLines  int
 	TotalBlankLines int
 	TotalComments   int
+	SkippedFiles    []analyzer.SkippedFile
+	Errors          []analyzer.FileError
+	Excluded        []analyzer.ProfileExclusion
+	// TestLanguages, TestFilesByLanguage and the TotalTest totals are the
+	// part of the results coming from test files, production code is the
+	// rest.
+	TestLanguages       map[string]*LanguageResult
+	TestFilesByLanguage map[string]int
+	TotalTestFiles      int
+	TotalTestLines      int
+	TotalTestCodeLines  int
+	TotalTestBlankLines int
+	TotalTestComments   int
+	// Embedded breaks down the lines of the files mixing several
+	// languages, by file language then by embedded language.
+	Embedded map[string]map[string]*LanguageResult
+
+	keepFiles bool
 }
 
-func (sc *Scanner) Summary(results []scanResult) *Summary {
-	summary := &Summary{
-		Languages:       make(map[string]*LanguageResult),
-		FilesByLanguage: make(map[string]int),
-		TotalFiles:      len(results),
+// NewSummary returns an empty summary. The result of each file is kept in
+// Files only if keepFiles is set, for the reports by file.
+func NewSummary(keepFiles bool) *Summary {
+	return &Summary{
+		Languages:           make(map[string]*LanguageResult),
+		FilesByLanguage:     make(map[string]int),
+		TestLanguages:       make(map[string]*LanguageResult),
+		TestFilesByLanguage: make(map[string]int),
+		keepFiles:           keepFiles,
 	}
+}
 
-	for _, result := range results {
-		language := result.Metadata.Language
-		if value, ok := summary.Languages[language]; ok {
-			value.Lines += result.Lines
-			value.CodeLines += result.CodeLines
-			value.BlankLines += result.BlankLines
-			value.Comments += result.Comments
-		} else {
-			summary.Languages[language] = &LanguageResult{
-				Lines:      result.Lines,
-				CodeLines:  result.CodeLines,
-				BlankLines: result.BlankLines,
-				Comments:   result.Comments,
-			}
+func (s *Summary) add(result scanResult) {
+	language := result.Metadata.Language
+	addFileLanguages(s.Languages, result)
+	if len(result.Embedded) > 0 {
+		// The lines of each section go to its own language, the file
+		// is still counted for the language it was detected as.
+		if s.Embedded == nil {
+			s.Embedded = make(map[string]map[string]*LanguageResult)
 		}
+		if s.Embedded[language] == nil {
+			s.Embedded[language] = make(map[string]*LanguageResult)
+		}
+		for embedded, part := range result.Embedded {
+			addLanguageResult(s.Embedded[language], embedded, part)
+		}
+	}
 
-		summary.Files = append(summary.Files, FileResult{
+	if s.keepFiles {
+		s.Files = append(s.Files, FileResult{
 			Path:       result.Metadata.FilePath,
 			Lines:      result.Lines,
 			CodeLines:  result.CodeLines,
 			BlankLines: result.BlankLines,
 			Comments:   result.Comments,
+			IsTest:     result.Metadata.IsTest,
+		})
+	}
+	s.FilesByLanguage[language]++
+	s.TotalFiles++
+	s.TotalLines += result.Lines
+	s.TotalCodeLines += result.CodeLines
+	s.TotalBlankLines += result.BlankLines
+	s.TotalComments += result.Comments
+
+	if result.Metadata.IsTest {
+		addFileLanguages(s.TestLanguages, result)
+		s.TestFilesByLanguage[language]++
+		s.TotalTestFiles++
+		s.TotalTestLines += result.Lines
+		s.TotalTestCodeLines += result.CodeLines
+		s.TotalTestBlankLines += result.BlankLines
+		s.TotalTestComments += result.Comments
+	}
+}
+
+// addFileLanguages adds the lines of a file to its language, or to the
+// languages of its sections.
+func addFileLanguages(languages map[string]*LanguageResult, result scanResult) {
+	language := result.Metadata.Language
+	if len(result.Embedded) == 0 {
+		addLanguageResult(languages, language, &LanguageResult{
+			Lines:      result.Lines,
+			CodeLines:  result.CodeLines,
+			BlankLines: result.BlankLines,
+			Comments:   result.Comments,
 		})
-		summary.FilesByLanguage[language]++
-		summary.TotalLines += result.Lines
-		summary.TotalCodeLines += result.CodeLines
-		summary.TotalBlankLines += result.BlankLines
-		summary.TotalComments += result.Comments
+		return
 	}
 
-	return summary
+	addLanguageResult(languages, language, &LanguageResult{})
+	for embedded, part := range result.Embedded {
+		addLanguageResult(languages, embedded, part)
+	}
+}
+
+func addLanguageResult(languages map[string]*LanguageResult, language string, result *LanguageResult) {
+	if value, ok := languages[language]; ok {
+		value.Lines += result.Lines
+		value.CodeLines += result.CodeLines
+		value.BlankLines += result.BlankLines
+		value.Comments += result.Comments
+		return
+	}
+
+	languages[language] = &LanguageResult{
+		Lines:      result.Lines,
+		CodeLines:  result.CodeLines,
+		BlankLines: result.BlankLines,
+		Comments:   result.Comments,
+	}
 }
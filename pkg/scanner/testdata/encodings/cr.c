@@ -0,0 +1 @@
+// Header comment#include <stdio.h>int main(void) { /* entry */    puts("héllo 😀");    return 0;}
\ No newline at end of file
@@ -0,0 +1,7 @@
+// Header comment
+#include <stdio.h>
+
+int main(void) { /* entry */
+    puts("héllo 😀");
+    return 0;
+}
@@ -0,0 +1,16 @@
+// Java sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+public class Sample {
+    String url = "http://example.com"; // trailing
+
+    /* one line block */
+    // line comment
+    String text = """
+        /* not a comment */
+        """;
+}
+
@@ -0,0 +1,7 @@
+% MATLAB sample
+%{
+block comment
+%}
+
+x = 1; % trailing
+disp(x)
@@ -0,0 +1,13 @@
+// Objective-C sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+#import <Foundation/Foundation.h>
+@interface Sample : NSObject // trailing
+
+    /* one line block */
+    // line comment
+@end
+
@@ -0,0 +1,4 @@
+-- Ada sample
+
+with Ada.Text_IO;
+procedure Sample is begin Ada.Text_IO.Put_Line ("-- not a comment"); end Sample; -- trailing
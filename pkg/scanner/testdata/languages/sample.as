@@ -0,0 +1,14 @@
+// ActionScript sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+package {
+    var url:String = "http://example.com"; // trailing
+
+    /* one line block */
+    // line comment
+    trace(url); /* done */
+}
+
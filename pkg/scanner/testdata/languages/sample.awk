@@ -0,0 +1,4 @@
+# AWK sample
+
+BEGIN { FS = "#" } # trailing
+{ print $1 }
@@ -0,0 +1,6 @@
+' VBA sample
+Rem second comment
+
+Sub Hello()
+    MsgBox "it's ' here" ' trailing
+End Sub
@@ -0,0 +1,8 @@
+:: Batch sample
+REM second comment
+@REM silent comment
+
+@echo off
+set NAME=world
+
+echo Hello %NAME%
@@ -0,0 +1,15 @@
+// C sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+#include <stdio.h>
+int main(void) { /* entry */
+
+    /* one line block */
+    // line comment
+    puts("// not a comment");
+    return 0;
+}
+
@@ -0,0 +1,7 @@
+;; Clojure sample
+; second comment
+
+(ns sample.core)
+(def url "http://example.com ; not a comment") ; trailing
+
+(defn hello [] (println url))
@@ -0,0 +1,13 @@
+// C++ sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+#include <string>
+std::string raw = R"(// raw)"; // trailing
+
+    /* one line block */
+    // line comment
+int main() { return 0; }
+
@@ -0,0 +1,14 @@
+// C# sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+class Sample {
+    string url = @"C:\path\"; // verbatim
+
+    /* one line block */
+    // line comment
+    string s = "/* not a comment */";
+}
+
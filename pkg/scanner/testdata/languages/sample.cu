@@ -0,0 +1,13 @@
+// CUDA sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+__global__ void add(int *a) {
+    a[threadIdx.x] += 1; // trailing
+
+    /* one line block */
+    // line comment
+}
+
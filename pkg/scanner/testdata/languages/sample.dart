@@ -0,0 +1,14 @@
+// Dart sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+void main() {
+  var s = 'http://example.com'; // trailing
+
+    /* one line block */
+    // line comment
+  print(s); /* outer /* inner */ still comment */
+}
+
@@ -0,0 +1,5 @@
+;;; sample.el --- Emacs Lisp sample
+
+(defun sample () "Say ; hi." (message "hi")) ; trailing
+  ;; indented comment
+(provide 'sample)
@@ -0,0 +1,6 @@
+-- Elm sample
+{- block {- nested -}
+   still comment -}
+
+module Main exposing (main)
+main = text "-- not a comment" -- trailing
@@ -0,0 +1,8 @@
+% Erlang sample
+% second comment line
+
+-module(sample).
+
+  % indented comment
+-export([hello/0]).
+hello() -> io:format("100% done~n"). % trailing
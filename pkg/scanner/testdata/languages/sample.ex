@@ -0,0 +1,8 @@
+# Elixir sample
+# second comment line
+
+defmodule Sample do
+
+  # indented comment
+  def url, do: "http://example.com#anchor"
+end
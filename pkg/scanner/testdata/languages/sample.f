@@ -0,0 +1,7 @@
+C     Fortran 77 sample
+*     star comment
+
+      PROGRAM SAMPLE
+      PRINT *, 'HELLO' ! trailing
+      ! free comment
+      END
@@ -0,0 +1,8 @@
+! Fortran sample
+! second comment line
+
+program sample
+
+  ! indented comment
+  print *, 'hello ! not a comment' ! trailing
+end program sample
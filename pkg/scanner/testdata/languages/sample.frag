@@ -0,0 +1,13 @@
+// GLSL sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+#version 330 core
+out vec4 color; // trailing
+
+    /* one line block */
+    // line comment
+void main() { color = vec4(1.0); }
+
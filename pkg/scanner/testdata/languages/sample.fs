@@ -0,0 +1,6 @@
+// F# sample
+(* block (* nested *)
+   still comment *)
+
+let url = "http://example.com" // trailing
+printfn "%s" url
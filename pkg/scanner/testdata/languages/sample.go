@@ -0,0 +1,15 @@
+// Golang sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+package main
+
+import "fmt" // trailing
+
+/* one line block */
+// line comment
+func main() {
+	fmt.Println(`/* raw */`, "http://example.com")
+}
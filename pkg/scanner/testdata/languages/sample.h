@@ -0,0 +1,14 @@
+// C Header sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+#ifndef SAMPLE_H
+#define SAMPLE_H /* guard */
+
+    /* one line block */
+    // line comment
+int sample(const char *s);
+#endif
+
@@ -0,0 +1,8 @@
+# HCL sample
+// slash comment
+/* block
+   comment */
+
+job "sample" { # trailing
+  url = "http://example.com"
+}
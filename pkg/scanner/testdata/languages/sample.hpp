@@ -0,0 +1,15 @@
+// C++ Header sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+#pragma once
+class Sample { // trailing
+
+    /* one line block */
+    // line comment
+public:
+    int value() const;
+};
+
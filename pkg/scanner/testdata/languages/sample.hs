@@ -0,0 +1,6 @@
+-- Haskell sample
+{- block {- nested -}
+   still comment -}
+
+module Main where
+main = putStrLn "-- not a comment" -- trailing
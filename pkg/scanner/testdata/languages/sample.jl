@@ -0,0 +1,6 @@
+# Julia sample
+#= block #= nested =#
+   still comment =#
+
+url = "http://example.com#anchor" # trailing
+println(url)
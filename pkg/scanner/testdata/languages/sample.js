@@ -0,0 +1,13 @@
+// JavaScript sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+const url = 'http://example.com'; // trailing
+const re = `/* ${url} */`;
+
+    /* one line block */
+    // line comment
+console.log(url, re);
+
@@ -0,0 +1,11 @@
+<%-- JSP sample --%>
+<%@ page contentType="text/html" %>
+<html>
+<!-- html comment -->
+<%
+  // java comment
+  String url = "http://example.com";
+%>
+
+<p><%= url %></p>
+</html>
@@ -0,0 +1,14 @@
+// Kotlin sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+fun main() {
+    val url = "http://example.com" // trailing
+
+    /* one line block */
+    // line comment
+    println(url) /* outer /* inner */ still */
+}
+
@@ -0,0 +1,5 @@
+;;; Lisp sample
+#| block #| nested |#
+   still comment |#
+
+(defun hello () (format t "; not a comment~%")) ; trailing
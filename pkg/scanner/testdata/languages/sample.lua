@@ -0,0 +1,7 @@
+-- Lua sample
+--[[ block
+     comment ]]
+--[==[ level two ]==]
+
+local url = "http://example.com -- not a comment" -- trailing
+print(url)
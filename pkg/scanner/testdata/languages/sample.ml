@@ -0,0 +1,6 @@
+(* OCaml sample *)
+(* block (* nested *)
+   still comment *)
+
+let url = "http://example.com (* not a comment *)"
+let () = print_endline url (* trailing *)
@@ -0,0 +1,6 @@
+# Nim sample
+#[ block #[ nested ]#
+   still comment ]#
+
+let url = "http://example.com#anchor" # trailing
+echo url
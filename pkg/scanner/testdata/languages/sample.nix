@@ -0,0 +1,6 @@
+# Nix sample
+/* block
+   comment */
+
+{ pkgs ? import <nixpkgs> {} }:
+pkgs.hello # trailing
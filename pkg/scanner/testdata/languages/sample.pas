@@ -0,0 +1,9 @@
+// Pascal sample
+{ brace
+  comment }
+(* star comment *)
+
+program Sample;
+begin
+  writeln('{ not a comment }'); // trailing
+end.
@@ -0,0 +1,13 @@
+<html>
+<!-- html comment -->
+<?php
+// PHP comment
+# hash comment
+/* block */
+$url = "http://example.com"; // trailing
+echo <<<EOT
+# not a comment
+EOT;
+?>
+
+</html>
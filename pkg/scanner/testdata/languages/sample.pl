@@ -0,0 +1,10 @@
+use strict;
+# Perl comment
+
+=pod
+Documentation.
+=cut
+my $url = "http://example.com#anchor"; # trailing
+print <<EOT;
+# not a comment
+EOT
@@ -0,0 +1,6 @@
+# PowerShell sample
+<# block
+   comment #>
+
+$url = "http://example.com#anchor" # trailing
+Write-Output $url
@@ -0,0 +1,8 @@
+"""Python sample."""
+# comment
+
+def hello():
+    '''Docstring
+    over lines.'''
+    url = "http://example.com#anchor"  # trailing
+    return url
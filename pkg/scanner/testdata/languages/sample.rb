@@ -0,0 +1,9 @@
+# Ruby sample
+=begin
+block comment
+=end
+
+url = "http://example.com#anchor" # trailing
+puts <<~EOT
+  # not a comment
+EOT
@@ -0,0 +1,14 @@
+// Rust sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+fn main() {
+    let url = "http://example.com"; // trailing
+
+    /* one line block */
+    // line comment
+    let raw = r#"/* raw */"#; /* outer /* inner */ still */
+}
+
@@ -0,0 +1,14 @@
+// Scala sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+object Sample {
+  val url = "http://example.com" // trailing
+
+    /* one line block */
+    // line comment
+  def main(args: Array[String]): Unit = println(url) /* a /* b */ c */
+}
+
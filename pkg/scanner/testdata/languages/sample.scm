@@ -0,0 +1,6 @@
+; Scheme sample
+#| block #| nested |#
+   still comment |#
+
+(define url "http://example.com ; not a comment") ; trailing
+(display url)
@@ -0,0 +1,8 @@
+#!/bin/sh
+# Shell sample
+
+url="http://example.com#anchor" # trailing
+cat <<EOF
+# not a comment
+EOF
+  # indented comment
@@ -0,0 +1,12 @@
+// Solidity sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+pragma solidity ^0.8.0;
+contract Sample { string url = "http://example.com"; } // trailing
+
+    /* one line block */
+    // line comment
+
@@ -0,0 +1,13 @@
+-- SQL sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+SELECT id, 'it''s -- here'
+FROM sample -- trailing
+
+    /* one line block */
+    -- line comment
+WHERE id = 1;
+
@@ -0,0 +1,13 @@
+// Swift sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+import Foundation
+let url = "http://example.com" // trailing
+
+    /* one line block */
+    // line comment
+print(url) /* a /* b */ c */
+
@@ -0,0 +1,7 @@
+# Tcl sample
+# second comment line
+
+set url "http://example.com#anchor" ;# trailing
+
+  # indented comment
+puts $url
@@ -0,0 +1,11 @@
+# Terraform sample
+// slash comment
+/* block
+   comment */
+
+resource "null_resource" "sample" { # trailing
+  triggers = { text = <<EOT
+# not a comment
+EOT
+  }
+}
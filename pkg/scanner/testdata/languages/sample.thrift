@@ -0,0 +1,7 @@
+// Thrift sample
+# hash comment
+/* block */
+
+service Sample { # trailing
+  string hello(1: string name)
+}
@@ -0,0 +1,13 @@
+// TypeScript sample
+/*
+ * Block comment over
+ * several lines.
+ */
+
+const url: string = 'http://example.com'; // trailing
+const re = `/* ${url} */`;
+
+    /* one line block */
+    // line comment
+export default re;
+
@@ -0,0 +1,6 @@
+-- VHDL sample
+/* block
+   comment */
+
+entity sample is -- trailing
+end entity;
@@ -0,0 +1,4 @@
+// Zig sample
+
+const std = @import("std");
+pub fn main() void { std.debug.print("// not a comment\n", .{}); } // trailing
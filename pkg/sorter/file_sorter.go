@@ -24,13 +24,7 @@ func (f FileSorter) OrderByLanguage(summary *scanner.Summary) *SortedSummary {
 
 	f.sortByFileName(results)
 
-	return &SortedSummary{
-		Results:         results,
-		TotalLines:      summary.TotalLines,
-		TotalCodeLines:  summary.TotalCodeLines,
-		TotalBlankLines: summary.TotalBlankLines,
-		TotalComments:   summary.TotalComments,
-	}
+	return f.sortedSummary(summary, results)
 }
 
 func (f FileSorter) OrderByCodeLines(summary *scanner.Summary) *SortedSummary {
@@ -38,13 +32,7 @@ func (f FileSorter) OrderByCodeLines(summary *scanner.Summary) *SortedSummary {
 
 	f.sortByCodeLines(results)
 
-	return &SortedSummary{
-		Results:         results,
-		TotalLines:      summary.TotalLines,
-		TotalCodeLines:  summary.TotalCodeLines,
-		TotalBlankLines: summary.TotalBlankLines,
-		TotalComments:   summary.TotalComments,
-	}
+	return f.sortedSummary(summary, results)
 }
 
 func (f FileSorter) OrderByLines(summary *scanner.Summary) *SortedSummary {
@@ -52,13 +40,7 @@ func (f FileSorter) OrderByLines(summary *scanner.Summary) *SortedSummary {
 
 	f.sortByLines(results)
 
-	return &SortedSummary{
-		Results:         results,
-		TotalLines:      summary.TotalLines,
-		TotalCodeLines:  summary.TotalCodeLines,
-		TotalBlankLines: summary.TotalBlankLines,
-		TotalComments:   summary.TotalComments,
-	}
+	return f.sortedSummary(summary, results)
 }
 
 func (f FileSorter) OrderByComments(summary *scanner.Summary) *SortedSummary {
@@ -66,13 +48,7 @@ func (f FileSorter) OrderByComments(summary *scanner.Summary) *SortedSummary {
 
 	f.sortByComments(results)
 
-	return &SortedSummary{
-		Results:         results,
-		TotalLines:      summary.TotalLines,
-		TotalCodeLines:  summary.TotalCodeLines,
-		TotalBlankLines: summary.TotalBlankLines,
-		TotalComments:   summary.TotalComments,
-	}
+	return f.sortedSummary(summary, results)
 }
 
 func (f FileSorter) OrderByBlankLines(summary *scanner.Summary) *SortedSummary {
@@ -80,12 +56,26 @@ func (f FileSorter) OrderByBlankLines(summary *scanner.Summary) *SortedSummary {
 
 	f.sortByBlankLines(results)
 
+	return f.sortedSummary(summary, results)
+}
+
+// sortedSummary returns the sorted results of the files with the totals of
+// the summary.
+func (f FileSorter) sortedSummary(summary *scanner.Summary, results []Result) *SortedSummary {
 	return &SortedSummary{
 		Results:         results,
 		TotalLines:      summary.TotalLines,
 		TotalCodeLines:  summary.TotalCodeLines,
 		TotalBlankLines: summary.TotalBlankLines,
 		TotalComments:   summary.TotalComments,
+		SkippedFiles:    summary.SkippedFiles,
+		Errors:          summary.Errors,
+		Excluded:        summary.Excluded,
+
+		TotalTestLines:      summary.TotalTestLines,
+		TotalTestCodeLines:  summary.TotalTestCodeLines,
+		TotalTestBlankLines: summary.TotalTestBlankLines,
+		TotalTestComments:   summary.TotalTestComments,
 	}
 }
 
@@ -99,6 +89,7 @@ func (f FileSorter) getResults(summary *scanner.Summary) []Result {
 			CodeLines:  result.CodeLines,
 			BlankLines: result.BlankLines,
 			Comments:   result.Comments,
+			IsTest:     result.IsTest,
 		})
 	}
 
@@ -107,13 +98,13 @@ func (f FileSorter) getResults(summary *scanner.Summary) []Result {
 
 func (f FileSorter) sortByFileName(results []Result) {
 	if f.sortOrder == "ASC" {
-		sort.Slice(results, func(i, j int) bool {
+		sort.SliceStable(results, func(i, j int) bool {
 			a := results[i].Name
 			b := results[j].Name
 			return a < b
 		})
 	} else if f.sortOrder == "DESC" {
-		sort.Slice(results, func(i, j int) bool {
+		sort.SliceStable(results, func(i, j int) bool {
 			a := results[i].Name
 			b := results[j].Name
 			return a > b
@@ -24,14 +24,7 @@ func (l LanguageSorter) OrderByLanguage(summary *scanner.Summary) *SortedSummary
 	sortedLanguages := l.sortLanguages(summary)
 
 	for _, language := range sortedLanguages {
-		result := summary.Languages[language]
-		results = append(results, Result{
-			Name:       language,
-			Lines:      result.Lines,
-			CodeLines:  result.CodeLines,
-			BlankLines: result.BlankLines,
-			Comments:   result.Comments,
-		})
+		results = append(results, l.getResult(summary, language))
 	}
 
 	return &SortedSummary{
@@ -42,6 +35,16 @@ func (l LanguageSorter) OrderByLanguage(summary *scanner.Summary) *SortedSummary
 		TotalCodeLines:  summary.TotalCodeLines,
 		TotalBlankLines: summary.TotalBlankLines,
 		TotalComments:   summary.TotalComments,
+		SkippedFiles:    summary.SkippedFiles,
+		Errors:          summary.Errors,
+		Excluded:        summary.Excluded,
+
+		TestFilesByLanguage: summary.TestFilesByLanguage,
+		TotalTestFiles:      summary.TotalTestFiles,
+		TotalTestLines:      summary.TotalTestLines,
+		TotalTestCodeLines:  summary.TotalTestCodeLines,
+		TotalTestBlankLines: summary.TotalTestBlankLines,
+		TotalTestComments:   summary.TotalTestComments,
 	}
 }
 
@@ -58,6 +61,16 @@ func (l LanguageSorter) OrderByCodeLines(summary *scanner.Summary) *SortedSummar
 		TotalCodeLines:  summary.TotalCodeLines,
 		TotalBlankLines: summary.TotalBlankLines,
 		TotalComments:   summary.TotalComments,
+		SkippedFiles:    summary.SkippedFiles,
+		Errors:          summary.Errors,
+		Excluded:        summary.Excluded,
+
+		TestFilesByLanguage: summary.TestFilesByLanguage,
+		TotalTestFiles:      summary.TotalTestFiles,
+		TotalTestLines:      summary.TotalTestLines,
+		TotalTestCodeLines:  summary.TotalTestCodeLines,
+		TotalTestBlankLines: summary.TotalTestBlankLines,
+		TotalTestComments:   summary.TotalTestComments,
 	}
 }
 
@@ -74,6 +87,16 @@ func (l LanguageSorter) OrderByLines(summary *scanner.Summary) *SortedSummary {
 		TotalCodeLines:  summary.TotalCodeLines,
 		TotalBlankLines: summary.TotalBlankLines,
 		TotalComments:   summary.TotalComments,
+		SkippedFiles:    summary.SkippedFiles,
+		Errors:          summary.Errors,
+		Excluded:        summary.Excluded,
+
+		TestFilesByLanguage: summary.TestFilesByLanguage,
+		TotalTestFiles:      summary.TotalTestFiles,
+		TotalTestLines:      summary.TotalTestLines,
+		TotalTestCodeLines:  summary.TotalTestCodeLines,
+		TotalTestBlankLines: summary.TotalTestBlankLines,
+		TotalTestComments:   summary.TotalTestComments,
 	}
 }
 
@@ -90,6 +113,16 @@ func (l LanguageSorter) OrderByComments(summary *scanner.Summary) *SortedSummary
 		TotalCodeLines:  summary.TotalCodeLines,
 		TotalBlankLines: summary.TotalBlankLines,
 		TotalComments:   summary.TotalComments,
+		SkippedFiles:    summary.SkippedFiles,
+		Errors:          summary.Errors,
+		Excluded:        summary.Excluded,
+
+		TestFilesByLanguage: summary.TestFilesByLanguage,
+		TotalTestFiles:      summary.TotalTestFiles,
+		TotalTestLines:      summary.TotalTestLines,
+		TotalTestCodeLines:  summary.TotalTestCodeLines,
+		TotalTestBlankLines: summary.TotalTestBlankLines,
+		TotalTestComments:   summary.TotalTestComments,
 	}
 }
 
@@ -106,6 +139,16 @@ func (l LanguageSorter) OrderByBlankLines(summary *scanner.Summary) *SortedSumma
 		TotalCodeLines:  summary.TotalCodeLines,
 		TotalBlankLines: summary.TotalBlankLines,
 		TotalComments:   summary.TotalComments,
+		SkippedFiles:    summary.SkippedFiles,
+		Errors:          summary.Errors,
+		Excluded:        summary.Excluded,
+
+		TestFilesByLanguage: summary.TestFilesByLanguage,
+		TotalTestFiles:      summary.TotalTestFiles,
+		TotalTestLines:      summary.TotalTestLines,
+		TotalTestCodeLines:  summary.TotalTestCodeLines,
+		TotalTestBlankLines: summary.TotalTestBlankLines,
+		TotalTestComments:   summary.TotalTestComments,
 	}
 }
 
@@ -134,6 +177,16 @@ func (l LanguageSorter) OrderByFiles(summary *scanner.Summary) *SortedSummary {
 		TotalCodeLines:  summary.TotalCodeLines,
 		TotalBlankLines: summary.TotalBlankLines,
 		TotalComments:   summary.TotalComments,
+		SkippedFiles:    summary.SkippedFiles,
+		Errors:          summary.Errors,
+		Excluded:        summary.Excluded,
+
+		TestFilesByLanguage: summary.TestFilesByLanguage,
+		TotalTestFiles:      summary.TotalTestFiles,
+		TotalTestLines:      summary.TotalTestLines,
+		TotalTestCodeLines:  summary.TotalTestCodeLines,
+		TotalTestBlankLines: summary.TotalTestBlankLines,
+		TotalTestComments:   summary.TotalTestComments,
 	}
 }
 
@@ -156,15 +209,29 @@ func (l LanguageSorter) sortLanguages(summary *scanner.Summary) []string {
 func (l LanguageSorter) getResults(summary *scanner.Summary) []Result {
 	results := []Result{}
 
-	for language, result := range summary.Languages {
-		results = append(results, Result{
-			Name:       language,
-			Lines:      result.Lines,
-			CodeLines:  result.CodeLines,
-			BlankLines: result.BlankLines,
-			Comments:   result.Comments,
-		})
+	for language := range summary.Languages {
+		results = append(results, l.getResult(summary, language))
 	}
 
 	return results
 }
+
+func (l LanguageSorter) getResult(summary *scanner.Summary, language string) Result {
+	result := summary.Languages[language]
+	sortedResult := Result{
+		Name:       language,
+		Lines:      result.Lines,
+		CodeLines:  result.CodeLines,
+		BlankLines: result.BlankLines,
+		Comments:   result.Comments,
+	}
+
+	if testResult, ok := summary.TestLanguages[language]; ok {
+		sortedResult.TestLines = testResult.Lines
+		sortedResult.TestCodeLines = testResult.CodeLines
+		sortedResult.TestBlankLines = testResult.BlankLines
+		sortedResult.TestComments = testResult.Comments
+	}
+
+	return sortedResult
+}
@@ -3,15 +3,23 @@ package sorter
 import (
 	"sort"
 
+	"github.com/colussim/GoLC/pkg/analyzer"
 	"github.com/colussim/GoLC/pkg/scanner"
 )
 
+// Result is the count of a language or a file. The Test counts are the
+// part coming from test files, IsTest flags a test file.
 type Result struct {
-	Name       string
-	Lines      int
-	CodeLines  int
-	BlankLines int
-	Comments   int
+	Name           string
+	Lines          int
+	CodeLines      int
+	BlankLines     int
+	Comments       int
+	IsTest         bool
+	TestLines      int
+	TestCodeLines  int
+	TestBlankLines int
+	TestComments   int
 }
 
 type SortedSummary struct {
@@ -22,6 +30,16 @@ type SortedSummary struct {
 	TotalCodeLines  int
 	TotalBlankLines int
 	TotalComments   int
+	SkippedFiles    []analyzer.SkippedFile
+	Errors          []analyzer.FileError
+	Excluded        []analyzer.ProfileExclusion
+
+	TestFilesByLanguage map[string]int
+	TotalTestFiles      int
+	TotalTestLines      int
+	TotalTestCodeLines  int
+	TotalTestBlankLines int
+	TotalTestComments   int
 }
 
 type Sorter interface {
@@ -38,13 +56,13 @@ type baseSorter struct {
 
 func (b baseSorter) sortByCodeLines(results []Result) {
 	if b.sortOrder == "ASC" {
-		sort.Slice(results, func(i, j int) bool {
+		sort.SliceStable(results, func(i, j int) bool {
 			a := results[i].CodeLines
 			b := results[j].CodeLines
 			return a < b
 		})
 	} else if b.sortOrder == "DESC" {
-		sort.Slice(results, func(i, j int) bool {
+		sort.SliceStable(results, func(i, j int) bool {
 			a := results[i].CodeLines
 			b := results[j].CodeLines
 			return a > b
@@ -54,13 +72,13 @@ func (b baseSorter) sortByCodeLines(results []Result) {
 
 func (b baseSorter) sortByLines(results []Result) {
 	if b.sortOrder == "ASC" {
-		sort.Slice(results, func(i, j int) bool {
+		sort.SliceStable(results, func(i, j int) bool {
 			a := results[i].Lines
 			b := results[j].Lines
 			return a < b
 		})
 	} else if b.sortOrder == "DESC" {
-		sort.Slice(results, func(i, j int) bool {
+		sort.SliceStable(results, func(i, j int) bool {
 			a := results[i].Lines
 			b := results[j].Lines
 			return a > b
@@ -70,13 +88,13 @@ func (b baseSorter) sortByLines(results []Result) {
 
 func (b baseSorter) sortByComments(results []Result) {
 	if b.sortOrder == "ASC" {
-		sort.Slice(results, func(i, j int) bool {
+		sort.SliceStable(results, func(i, j int) bool {
 			a := results[i].Comments
 			b := results[j].Comments
 			return a < b
 		})
 	} else if b.sortOrder == "DESC" {
-		sort.Slice(results, func(i, j int) bool {
+		sort.SliceStable(results, func(i, j int) bool {
 			a := results[i].Comments
 			b := results[j].Comments
 			return a > b
@@ -86,13 +104,13 @@ func (b baseSorter) sortByComments(results []Result) {
 
 func (b baseSorter) sortByBlankLines(results []Result) {
 	if b.sortOrder == "ASC" {
-		sort.Slice(results, func(i, j int) bool {
+		sort.SliceStable(results, func(i, j int) bool {
 			a := results[i].BlankLines
 			b := results[j].BlankLines
 			return a < b
 		})
 	} else if b.sortOrder == "DESC" {
-		sort.Slice(results, func(i, j int) bool {
+		sort.SliceStable(results, func(i, j int) bool {
 			a := results[i].BlankLines
 			b := results[j].BlankLines
 			return a > b
@@ -3,6 +3,7 @@ package main
 import (
 	"archive/zip"
 	"bufio"
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -405,13 +406,15 @@ func performRepoAnalysis(params RepoParams, DestinationResult string, spin *spin
 	spin.Suffix = MessB
 	spin.Start()
 
-	gc, err := goloc.NewGCloc(golocParams, assets.Languages)
+	gc, err := goloc.NewGCloc(context.Background(), golocParams, assets.Languages)
 	if err != nil {
 		fmt.Println(errorMessageRepo, err)
 		return
 	}
 
-	gc.Run()
+	if _, err := gc.Run(context.Background()); err != nil {
+		fmt.Println(errorMessageRepo, err)
+	}
 	*count++
 
 	// Remove Repository Directory
@@ -527,13 +530,15 @@ func AnalyseReposListFile(Listdirectorie, fileexclusionEX []string) {
 				Token:             "",
 			}
 
-			gc, err := goloc.NewGCloc(params, assets.Languages)
+			gc, err := goloc.NewGCloc(context.Background(), params, assets.Languages)
 			if err != nil {
 				fmt.Println(errorMessageRepo, err)
 				return
 			}
 
-			gc.Run()
+			if _, err := gc.Run(context.Background()); err != nil {
+				fmt.Println(errorMessageRepo, err)
+			}
 			spin.Stop()
 			fmt.Printf("\r\t✅ %d The directory <%s> has been analyzed\n", count, dir)
 			count++
@@ -546,13 +551,15 @@ func AnalyseReposListFile(Listdirectorie, fileexclusionEX []string) {
 /* ---------------- End Analyse Directory ---------------- */
 
 func AnalyseRun(params goloc.Params, reponame string) {
-	gc, err := goloc.NewGCloc(params, assets.Languages)
+	gc, err := goloc.NewGCloc(context.Background(), params, assets.Languages)
 	if err != nil {
 		fmt.Println(errorMessageRepo, err)
 		os.Exit(1)
 	}
 
-	gc.Run()
+	if _, err := gc.Run(context.Background()); err != nil {
+		fmt.Println(errorMessageRepo, err)
+	}
 }
 
 func AnalyseRepo(DestinationResult string, Users string, AccessToken string, DevOps string, Organization string, reponame string) (cpt int) {
@@ -578,13 +585,15 @@ func AnalyseRepo(DestinationResult string, Users string, AccessToken string, Dev
 		OutputPath:        DestinationResult,
 		ReportFormats:     []string{"json"},
 	}
-	gc, err := goloc.NewGCloc(params, assets.Languages)
+	gc, err := goloc.NewGCloc(context.Background(), params, assets.Languages)
 	if err != nil {
 		fmt.Println(errorMessageRepo, err)
 		os.Exit(1)
 	}
 
-	gc.Run()
+	if _, err := gc.Run(context.Background()); err != nil {
+		fmt.Println(errorMessageRepo, err)
+	}
 	cpt++
 
 	// Remove Repository Directory